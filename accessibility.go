@@ -0,0 +1,76 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"unsafe"
+)
+
+import . "github.com/lxn/go-winapi"
+
+var accPropServicesInstance *iAccPropServices
+
+// accPropServices returns the process wide *iAccPropServices used to annotate
+// the MSAA properties of widgets, creating it on first use.
+func accPropServices() (*iAccPropServices, error) {
+	if accPropServicesInstance != nil {
+		return accPropServicesInstance, nil
+	}
+
+	var classFactoryPtr unsafe.Pointer
+	if hr := CoGetClassObject(&clsidAccPropServices, CLSCTX_INPROC_SERVER, nil, &IID_IClassFactory, &classFactoryPtr); FAILED(hr) {
+		return nil, errorFromHRESULT("CoGetClassObject(CLSID_AccPropServices)", hr)
+	}
+
+	classFactory := (*IClassFactory)(classFactoryPtr)
+	defer classFactory.Release()
+
+	var ptr unsafe.Pointer
+	if hr := classFactory.CreateInstance(nil, &iidIAccPropServices, &ptr); FAILED(hr) {
+		return nil, errorFromHRESULT("IClassFactory.CreateInstance", hr)
+	}
+
+	accPropServicesInstance = (*iAccPropServices)(ptr)
+
+	return accPropServicesInstance, nil
+}
+
+// setAccessibleProp annotates the property prop of the MSAA element idChild of
+// the client object of hwnd with value. An empty value removes the annotation,
+// so the default of the control applies again.
+func setAccessibleProp(hwnd HWND, idChild uint32, prop msaaPropID, value string) error {
+	aps, err := accPropServices()
+	if err != nil {
+		return err
+	}
+
+	if value == "" {
+		if hr := aps.ClearHwndProps(hwnd, objIDClient, idChild, []msaaPropID{prop}); FAILED(hr) {
+			return errorFromHRESULT("IAccPropServices.ClearHwndProps", hr)
+		}
+
+		return nil
+	}
+
+	if hr := aps.SetHwndPropStr(hwnd, objIDClient, idChild, &prop, value); FAILED(hr) {
+		return errorFromHRESULT("IAccPropServices.SetHwndPropStr", hr)
+	}
+
+	return nil
+}
+
+// clearAccessibleProps removes the annotations of the MSAA element idChild of
+// the client object of hwnd.
+func clearAccessibleProps(hwnd HWND, idChild uint32) {
+	if accPropServicesInstance == nil {
+		return
+	}
+
+	accPropServicesInstance.ClearHwndProps(
+		hwnd,
+		objIDClient,
+		idChild,
+		[]msaaPropID{propIDAccName, propIDAccValue})
+}
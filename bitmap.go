@@ -6,6 +6,7 @@ package walk
 
 import (
 	"fmt"
+	"math"
 	"syscall"
 	"unsafe"
 )
@@ -189,6 +190,23 @@ func (bmp *Bitmap) drawStretched(hdc HDC, bounds Rectangle) error {
 	})
 }
 
+// pixels returns a copy of the current pixel data of the *Bitmap.
+func (bmp *Bitmap) pixels() ([]byte, error) {
+	GdiFlush()
+
+	var dib DIBSECTION
+	if GetObject(HGDIOBJ(bmp.hBmp), unsafe.Sizeof(dib), unsafe.Pointer(&dib)) == 0 {
+		return nil, newError("GetObject failed")
+	}
+
+	n := int(dib.DsBm.BmWidthBytes * dib.DsBm.BmHeight)
+
+	pixels := make([]byte, n)
+	copy(pixels, (*[1 << 30]byte)(dib.DsBm.BmBits)[:n])
+
+	return pixels, nil
+}
+
 func (bmp *Bitmap) handle() HBITMAP {
 	return bmp.hBmp
 }
@@ -208,3 +226,117 @@ func (bmp *Bitmap) Dispose() {
 func (bmp *Bitmap) Size() Size {
 	return bmp.size
 }
+
+// RotateBitmap returns a new *Bitmap that contains src rotated clockwise by
+// degrees.
+//
+// The new *Bitmap is large enough to contain the whole rotated image, areas
+// not covered by it are black.
+func RotateBitmap(src *Bitmap, degrees int) (*Bitmap, error) {
+	if src == nil {
+		return nil, newError("src cannot be nil")
+	}
+
+	size := src.Size()
+	w, h := float64(size.Width), float64(size.Height)
+
+	rad := float64(degrees%360) * math.Pi / 180
+	sin, cos := math.Sin(rad), math.Cos(rad)
+
+	// Avoid off by one sizes for multiples of 90 degrees.
+	if degrees%90 == 0 {
+		sin, cos = math.Floor(sin+0.5), math.Floor(cos+0.5)
+	}
+
+	dstSize := Size{
+		int(math.Ceil(math.Abs(w*cos) + math.Abs(h*sin))),
+		int(math.Ceil(math.Abs(w*sin) + math.Abs(h*cos))),
+	}
+
+	// PlgBlt maps the upper-left, upper-right and lower-left corners of the
+	// source to these points.
+	var points [3]POINT
+	for i, p := range [3][2]float64{{-w / 2, -h / 2}, {w / 2, -h / 2}, {-w / 2, h / 2}} {
+		points[i] = POINT{
+			X: int32(math.Floor(float64(dstSize.Width)/2 + p[0]*cos - p[1]*sin + 0.5)),
+			Y: int32(math.Floor(float64(dstSize.Height)/2 + p[0]*sin + p[1]*cos + 0.5)),
+		}
+	}
+
+	return newTransformedBitmap(src, dstSize, func(hdcDst, hdcSrc HDC) error {
+		if ret, _, _ := plgBlt.Call(
+			uintptr(hdcDst),
+			uintptr(unsafe.Pointer(&points[0])),
+			uintptr(hdcSrc),
+			0,
+			0,
+			uintptr(size.Width),
+			uintptr(size.Height),
+			0,
+			0,
+			0); ret == 0 {
+
+			return newError("PlgBlt failed")
+		}
+
+		return nil
+	})
+}
+
+// FlipBitmap returns a new *Bitmap that contains src mirrored horizontally or
+// vertically.
+func FlipBitmap(src *Bitmap, horizontal bool) (*Bitmap, error) {
+	if src == nil {
+		return nil, newError("src cannot be nil")
+	}
+
+	size := src.Size()
+
+	x, y, width, height := 0, 0, size.Width, size.Height
+	if horizontal {
+		x, width = size.Width-1, -size.Width
+	} else {
+		y, height = size.Height-1, -size.Height
+	}
+
+	return newTransformedBitmap(src, size, func(hdcDst, hdcSrc HDC) error {
+		if !StretchBlt(
+			hdcDst,
+			0,
+			0,
+			int32(size.Width),
+			int32(size.Height),
+			hdcSrc,
+			int32(x),
+			int32(y),
+			int32(width),
+			int32(height),
+			SRCCOPY) {
+
+			return newError("StretchBlt failed")
+		}
+
+		return nil
+	})
+}
+
+// newTransformedBitmap creates a *Bitmap of the specified size and calls f with
+// memory DCs that have the new bitmap and src selected.
+func newTransformedBitmap(src *Bitmap, size Size, f func(hdcDst, hdcSrc HDC) error) (*Bitmap, error) {
+	dst, err := NewBitmap(size)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dst.withSelectedIntoMemDC(func(hdcDst HDC) error {
+		return src.withSelectedIntoMemDC(func(hdcSrc HDC) error {
+			return f(hdcDst, hdcSrc)
+		})
+	})
+	if err != nil {
+		dst.Dispose()
+		return nil, err
+	}
+
+	return dst, nil
+}
@@ -209,3 +209,49 @@ func (b *BitmapBrush) logbrush() *LOGBRUSH {
 func (b *BitmapBrush) Bitmap() *Bitmap {
 	return b.bitmap
 }
+
+// solidColorBrushCacheEntry is a reference counted *SolidColorBrush.
+type solidColorBrushCacheEntry struct {
+	brush *SolidColorBrush
+	refs  int
+}
+
+// solidColorBrushCache shares solid color brushes between widgets, so painting
+// code doesn't create a GDI brush per color and widget.
+var solidColorBrushCache = make(map[Color]*solidColorBrushCacheEntry)
+
+// acquireSolidColorBrush returns the shared *SolidColorBrush for color and
+// increments its reference count. Each call must be balanced by a call to
+// releaseSolidColorBrush.
+func acquireSolidColorBrush(color Color) (*SolidColorBrush, error) {
+	if entry, ok := solidColorBrushCache[color]; ok {
+		entry.refs++
+		return entry.brush, nil
+	}
+
+	brush, err := NewSolidColorBrush(color)
+	if err != nil {
+		return nil, err
+	}
+
+	solidColorBrushCache[color] = &solidColorBrushCacheEntry{brush: brush, refs: 1}
+
+	return brush, nil
+}
+
+// releaseSolidColorBrush decrements the reference count of the shared brush for
+// color and disposes it, when it is no longer referenced.
+func releaseSolidColorBrush(color Color) {
+	entry, ok := solidColorBrushCache[color]
+	if !ok {
+		return
+	}
+
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+
+	entry.brush.Dispose()
+	delete(solidColorBrushCache, color)
+}
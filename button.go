@@ -4,6 +4,11 @@
 
 package walk
 
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
 import . "github.com/lxn/go-winapi"
 
 type clickable interface {
@@ -12,13 +17,112 @@ type clickable interface {
 
 type Button struct {
 	WidgetBase
+	icon             *Icon
+	hIcon            HICON
+	iconSize         Size
 	clickedPublisher EventPublisher
 }
 
+// Dispose releases the operating system resources associated with the
+// *Button.
+func (b *Button) Dispose() {
+	b.WidgetBase.Dispose()
+
+	if b.hIcon != 0 {
+		DestroyIcon(b.hIcon)
+		b.hIcon = 0
+	}
+}
+
+// Icon returns the icon displayed by the *Button, or nil.
+func (b *Button) Icon() *Icon {
+	return b.icon
+}
+
+// SetIcon has the *Button display icon instead of text, e.g. for a command
+// button in a tool area. Pass nil to display text again.
+//
+// The *Button uses its own copy of the icon, so icon may be disposed of after
+// SetIcon returns.
+func (b *Button) SetIcon(icon *Icon) error {
+	var hIcon HICON
+	var iconSize Size
+
+	if icon != nil {
+		var err error
+		if iconSize, err = icon.size(); err != nil {
+			return err
+		}
+
+		if hIcon, err = icon.copyHandle(); err != nil {
+			return err
+		}
+	}
+
+	if err := b.ensureStyleBits(BS_ICON, icon != nil); err != nil {
+		if hIcon != 0 {
+			DestroyIcon(hIcon)
+		}
+		return err
+	}
+
+	if icon != nil {
+		if err := setWidgetText(b.hWnd, ""); err != nil {
+			DestroyIcon(hIcon)
+			return err
+		}
+	}
+
+	b.SendMessage(BM_SETIMAGE, IMAGE_ICON, uintptr(hIcon))
+
+	if b.hIcon != 0 {
+		DestroyIcon(b.hIcon)
+	}
+
+	b.icon = icon
+	b.hIcon = hIcon
+	b.iconSize = iconSize
+
+	return b.updateParentLayout()
+}
+
+// CaptureImage returns a new *Bitmap of the size of the *Button, that the
+// *Button has rendered itself into, e.g. to preview a theme.
+func (b *Button) CaptureImage() (*Bitmap, error) {
+	size := b.Bounds().Size()
+	if size.Width <= 0 || size.Height <= 0 {
+		return nil, newError("cannot capture a widget of zero size")
+	}
+
+	bmp, err := NewBitmap(size)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bmp.withSelectedIntoMemDC(func(hdcMem HDC) error {
+		b.SendMessage(
+			WM_PRINT,
+			uintptr(hdcMem),
+			PRF_NONCLIENT|PRF_CLIENT|PRF_ERASEBKGND|PRF_CHILDREN)
+
+		return nil
+	})
+	if err != nil {
+		bmp.Dispose()
+		return nil, err
+	}
+
+	return bmp, nil
+}
+
 func (b *Button) Text() string {
 	return widgetText(b.hWnd)
 }
 
+// SetText sets the text of the *Button.
+//
+// An ampersand marks the following character as mnemonic, e.g. "&Save" can be
+// clicked by pressing Alt+S. Use "&&" for a literal ampersand.
 func (b *Button) SetText(value string) error {
 	if value == b.Text() {
 		return nil
@@ -31,6 +135,32 @@ func (b *Button) SetText(value string) error {
 	return b.updateParentLayout()
 }
 
+// Mnemonic returns the upper case mnemonic character of the text of the
+// *Button, or 0 if it has none.
+func (b *Button) Mnemonic() rune {
+	return mnemonic(b.Text())
+}
+
+// mnemonic returns the upper case character following the first single
+// ampersand in text, or 0 if there is none.
+func mnemonic(text string) rune {
+	for i := 0; i < len(text)-1; i++ {
+		if text[i] != '&' {
+			continue
+		}
+
+		i++
+		if text[i] == '&' {
+			continue
+		}
+
+		r, _ := utf8.DecodeRuneInString(text[i:])
+		return unicode.ToUpper(r)
+	}
+
+	return 0
+}
+
 func (b *Button) Checked() bool {
 	return b.SendMessage(BM_GETCHECK, 0, 0) == BST_CHECKED
 }
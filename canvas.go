@@ -234,6 +234,14 @@ func (c *Canvas) DrawImageStretched(image Image, bounds Rectangle) error {
 	return image.drawStretched(c.hdc, bounds)
 }
 
+func (c *Canvas) DrawMetafileBlended(mf *Metafile, bounds Rectangle, alpha byte) error {
+	if mf == nil {
+		return newError("mf cannot be nil")
+	}
+
+	return mf.drawBlended(c.hdc, bounds, alpha)
+}
+
 func (c *Canvas) DrawLine(pen Pen, from, to Point) error {
 	if !MoveToEx(c.hdc, from.X, from.Y, nil) {
 		return newError("MoveToEx failed")
@@ -272,6 +280,48 @@ func (c *Canvas) FillRectangle(brush Brush, bounds Rectangle) error {
 	return c.rectangle(brush, nullPenSingleton, bounds, 1)
 }
 
+// DrawRangeIndicator draws a horizontal gauge into bounds, that shows where
+// value is located within the range from min to max, e.g. next to a
+// *NumberEdit on a dashboard.
+//
+// The outline of bounds is drawn in color and filled proportionally from the
+// left. A value outside the range is clamped to it.
+func (c *Canvas) DrawRangeIndicator(bounds Rectangle, value, min, max float64, color Color) error {
+	if max <= min {
+		return newError("max must be greater than min")
+	}
+
+	if value < min {
+		value = min
+	} else if value > max {
+		value = max
+	}
+
+	pen, err := NewCosmeticPen(PenSolid, color)
+	if err != nil {
+		return err
+	}
+	defer pen.Dispose()
+
+	brush, err := NewSolidColorBrush(color)
+	if err != nil {
+		return err
+	}
+	defer brush.Dispose()
+
+	if err := c.DrawRectangle(pen, bounds); err != nil {
+		return err
+	}
+
+	fill := bounds
+	fill.Width = int(float64(bounds.Width)*(value-min)/(max-min) + 0.5)
+	if fill.Width == 0 {
+		return nil
+	}
+
+	return c.FillRectangle(brush, fill)
+}
+
 func (c *Canvas) DrawText(text string, font *Font, color Color, bounds Rectangle, format DrawTextFormat) error {
 	return c.withFontAndTextColor(font, color, func() error {
 		rect := bounds.toRECT()
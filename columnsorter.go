@@ -0,0 +1,199 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ColumnSorter implements the Sorter interface for a TableModel, based on the
+// LessFunc of its columns.
+//
+// Columns without a LessFunc are sorted by the natural order of their values,
+// if those are strings, numbers, bools, time.Time or *big.Rat values. nil
+// values sort first. Sorting is stable, so rows with equal values keep their
+// order.
+//
+// Embed a ColumnSorter into your model instead of a SorterBase and call its
+// SetModel method with the model. The model must implement the Swap method of
+// sort.Interface, so the ColumnSorter can reorder the rows.
+type ColumnSorter struct {
+	SorterBase
+	model TableModel
+}
+
+// Model returns the TableModel sorted by the ColumnSorter.
+func (cs *ColumnSorter) Model() TableModel {
+	return cs.model
+}
+
+// SetModel sets the TableModel sorted by the ColumnSorter.
+func (cs *ColumnSorter) SetModel(model TableModel) {
+	cs.model = model
+}
+
+// ColumnSortable returns whether column col has a LessFunc or values of a type
+// that the ColumnSorter knows how to compare.
+func (cs *ColumnSorter) ColumnSortable(col int) bool {
+	if cs.model == nil {
+		return false
+	}
+
+	if cs.model.Columns()[col].LessFunc != nil {
+		return true
+	}
+
+	count := cs.model.RowCount()
+	for row := 0; row < count; row++ {
+		if value := cs.model.Value(row, col); value != nil {
+			_, ok := lessValues(value, value)
+			return ok
+		}
+	}
+
+	return false
+}
+
+// Sort sorts the rows of the model by column col in order order and publishes
+// the SortChanged event.
+func (cs *ColumnSorter) Sort(col int, order SortOrder) error {
+	if col > -1 {
+		if cs.model == nil {
+			return newError("ColumnSorter: model must not be nil")
+		}
+
+		swapper, ok := cs.model.(interface {
+			Swap(i, j int)
+		})
+		if !ok {
+			return newError("ColumnSorter: model must implement Swap")
+		}
+
+		less := cs.model.Columns()[col].LessFunc
+		if less == nil {
+			less = lessValuesOrdered
+		}
+
+		sort.Stable(&columnSorterRows{cs.model, swapper.Swap, less, cs.tieBreaker, col, order})
+	}
+
+	return cs.SorterBase.Sort(col, order)
+}
+
+// AttachToReset attaches to reset, usually the RowsReset event of the model,
+// and sorts the rows again by the sorted column each time reset is published,
+// e.g. after the rows were reloaded. It returns the handle to detach from
+// reset.
+func (cs *ColumnSorter) AttachToReset(reset *Event) int {
+	return reset.Attach(func() {
+		if col := cs.SortedColumn(); col > -1 {
+			cs.Sort(col, cs.SortOrder())
+		}
+	})
+}
+
+type columnSorterRows struct {
+	model      TableModel
+	swap       func(i, j int)
+	less       func(a, b interface{}) bool
+	tieBreaker func(i, j int) bool
+	col        int
+	order      SortOrder
+}
+
+func (r *columnSorterRows) Len() int {
+	return r.model.RowCount()
+}
+
+func (r *columnSorterRows) Less(i, j int) bool {
+	a, b := r.model.Value(i, r.col), r.model.Value(j, r.col)
+
+	if r.tieBreaker != nil && !r.less(a, b) && !r.less(b, a) {
+		return r.tieBreaker(i, j)
+	}
+
+	if r.order == SortDescending {
+		return r.less(b, a)
+	}
+
+	return r.less(a, b)
+}
+
+func (r *columnSorterRows) Swap(i, j int) {
+	r.swap(i, j)
+}
+
+// lessValuesOrdered extends lessValues to a strict weak ordering of all values.
+// nil comes first and values lessValues can not compare with each other are
+// ordered by their class, see valueOrderClass.
+func lessValuesOrdered(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+
+	if less, ok := lessValues(a, b); ok {
+		return less
+	}
+
+	return valueOrderClass(a) < valueOrderClass(b)
+}
+
+// valueOrderClass returns the name of the class of values that lessValues
+// compares with each other.
+func valueOrderClass(v interface{}) string {
+	switch v.(type) {
+	case time.Time:
+		return "time.Time"
+
+	case *big.Rat:
+		return "*big.Rat"
+	}
+
+	return reflect.TypeOf(v).Kind().String()
+}
+
+// lessValues reports if a is less than b and if it knows how to compare them.
+func lessValues(a, b interface{}) (less, ok bool) {
+	switch a := a.(type) {
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Before(b), true
+		}
+		return false, false
+
+	case *big.Rat:
+		if b, ok := b.(*big.Rat); ok {
+			return a.Cmp(b) < 0, true
+		}
+		return false, false
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Kind() != vb.Kind() {
+		return false, false
+	}
+
+	switch va.Kind() {
+	case reflect.String:
+		return va.String() < vb.String(), true
+
+	case reflect.Bool:
+		return !va.Bool() && vb.Bool(), true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return va.Int() < vb.Int(), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return va.Uint() < vb.Uint(), true
+
+	case reflect.Float32, reflect.Float64:
+		return va.Float() < vb.Float(), true
+	}
+
+	return false, false
+}
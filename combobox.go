@@ -7,6 +7,7 @@ package walk
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"syscall"
 	"time"
 	"unsafe"
@@ -14,6 +15,28 @@ import (
 
 import . "github.com/lxn/go-winapi"
 
+// number of items a drop down list shows by default
+const comboBoxDropDownItemCount = 30
+
+// time after which typing starts a new autocompletion prefix
+const comboBoxAutoCompleteTimeout = time.Second
+
+// AutoCompleteMode specifies how a ComboBox reacts to typing.
+type AutoCompleteMode int
+
+const (
+	// AutoCompleteNone leaves typing to the native control.
+	AutoCompleteNone AutoCompleteMode = iota
+
+	// AutoCompletePrefix selects the first item whose text starts with the
+	// characters typed so far.
+	AutoCompletePrefix
+
+	// AutoCompletePrefixDropDown is like AutoCompletePrefix, but also shows the
+	// drop down list while typing.
+	AutoCompletePrefixDropDown
+)
+
 type ComboBox struct {
 	WidgetBase
 	bindingMember                string
@@ -23,9 +46,14 @@ type ComboBox struct {
 	precision                    int
 	itemsResetHandlerHandle      int
 	itemChangedHandlerHandle     int
+	itemsChangedHandlerHandle    int
 	maxItemTextWidth             int
 	prevCurIndex                 int
 	selChangeIndex               int
+	autoCompleteMode             AutoCompleteMode
+	autoCompletePrefix           string
+	autoCompleteLastKey          time.Time
+	ownerDrawn                   bool
 	currentIndexChangedPublisher EventPublisher
 }
 
@@ -36,11 +64,14 @@ func NewComboBox(parent Container) (*ComboBox, error) {
 		cb,
 		parent,
 		"COMBOBOX",
-		WS_TABSTOP|WS_VISIBLE|WS_VSCROLL|CBS_DROPDOWNLIST,
+		WS_TABSTOP|WS_VISIBLE|WS_VSCROLL|CBS_DROPDOWNLIST|CBS_HASSTRINGS,
 		0); err != nil {
 		return nil, err
 	}
 
+	// The parent finds us by id to forward WM_MEASUREITEM.
+	SetWindowLong(cb.hWnd, GWLP_ID, newCtrlID())
+
 	return cb, nil
 }
 
@@ -157,6 +188,8 @@ func (cb *ComboBox) resetItems() error {
 		return nil
 	}
 
+	cb.updateVisibleRange()
+
 	count := cb.model.ItemCount()
 
 	for i := 0; i < count; i++ {
@@ -168,27 +201,61 @@ func (cb *ComboBox) resetItems() error {
 	return nil
 }
 
+func (cb *ComboBox) updateVisibleRange() {
+	lazy, ok := cb.model.(LazyListModel)
+	if !ok {
+		return
+	}
+
+	top := int(cb.SendMessage(CB_GETTOPINDEX, 0, 0))
+	if top < 0 {
+		top = 0
+	}
+
+	lazy.SetVisibleRange(top, comboBoxDropDownItemCount)
+}
+
 func (cb *ComboBox) attachModel() {
 	itemsResetHandler := func() {
 		cb.resetItems()
 	}
 	cb.itemsResetHandlerHandle = cb.model.ItemsReset().Attach(itemsResetHandler)
 
-	itemChangedHandler := func(index int) {
-		if CB_ERR == cb.SendMessage(CB_DELETESTRING, uintptr(index), 0) {
-			newError("SendMessage(CB_DELETESTRING)")
-		}
+	itemsChangedHandler := func(from, to int) {
+		// Redraw once for the whole block instead of once per item.
+		cb.SetSuspended(true)
+		defer func() {
+			cb.SetSuspended(false)
+			cb.Invalidate()
+		}()
+
+		for index := from; index <= to; index++ {
+			if CB_ERR == cb.SendMessage(CB_DELETESTRING, uintptr(index), 0) {
+				newError("SendMessage(CB_DELETESTRING)")
+			}
 
-		cb.insertItemAt(index)
+			cb.insertItemAt(index)
+		}
 
 		cb.SetCurrentIndex(cb.prevCurIndex)
 	}
-	cb.itemChangedHandlerHandle = cb.model.ItemChanged().Attach(itemChangedHandler)
+
+	if icn, ok := cb.model.(ItemsChangedNotifier); ok {
+		cb.itemsChangedHandlerHandle = icn.ItemsChanged().Attach(itemsChangedHandler)
+	} else {
+		cb.itemChangedHandlerHandle = cb.model.ItemChanged().Attach(func(index int) {
+			itemsChangedHandler(index, index)
+		})
+	}
 }
 
 func (cb *ComboBox) detachModel() {
 	cb.model.ItemsReset().Detach(cb.itemsResetHandlerHandle)
-	cb.model.ItemChanged().Detach(cb.itemChangedHandlerHandle)
+	if icn, ok := cb.model.(ItemsChangedNotifier); ok {
+		icn.ItemsChanged().Detach(cb.itemsChangedHandlerHandle)
+	} else {
+		cb.model.ItemChanged().Detach(cb.itemChangedHandlerHandle)
+	}
 }
 
 func (cb *ComboBox) Model() ListModel {
@@ -203,6 +270,10 @@ func (cb *ComboBox) SetModel(model ListModel) error {
 	cb.model = model
 	cb.bindingValueProvider, _ = model.(BindingValueProvider)
 
+	if err := cb.applyOwnerDraw(); err != nil {
+		return err
+	}
+
 	if model != nil {
 		cb.attachModel()
 	}
@@ -210,6 +281,35 @@ func (cb *ComboBox) SetModel(model ListModel) error {
 	return cb.resetItems()
 }
 
+// applyOwnerDraw makes the *ComboBox owner drawn if its model implements
+// ItemFontProvider or EditTextProvider, and system drawn otherwise.
+func (cb *ComboBox) applyOwnerDraw() error {
+	_, hasItemFonts := cb.model.(ItemFontProvider)
+	_, hasEditTexts := cb.model.(EditTextProvider)
+
+	ownerDrawn := hasItemFonts || hasEditTexts
+	if ownerDrawn == cb.ownerDrawn {
+		return nil
+	}
+
+	style := uint32(GetWindowLong(cb.hWnd, GWL_STYLE))
+	if ownerDrawn {
+		style |= CBS_OWNERDRAWFIXED
+	} else {
+		style &^= CBS_OWNERDRAWFIXED
+	}
+
+	// WM_SETFONT of the new window already needs to know.
+	cb.ownerDrawn = ownerDrawn
+
+	if err := cb.recreateWindow("COMBOBOX", style); err != nil {
+		cb.ownerDrawn = !ownerDrawn
+		return err
+	}
+
+	return nil
+}
+
 func (cb *ComboBox) Format() string {
 	return cb.format
 }
@@ -255,6 +355,79 @@ func (cb *ComboBox) calculateMaxItemTextWidth() int {
 	return maxWidth
 }
 
+// AutoCompleteMode returns how the *ComboBox reacts to typing.
+func (cb *ComboBox) AutoCompleteMode() AutoCompleteMode {
+	return cb.autoCompleteMode
+}
+
+// SetAutoCompleteMode sets how the *ComboBox reacts to typing.
+//
+// If the model implements PrefixMatcher, it is used to find matching items,
+// otherwise the item texts are compared.
+func (cb *ComboBox) SetAutoCompleteMode(mode AutoCompleteMode) {
+	cb.autoCompleteMode = mode
+	cb.autoCompletePrefix = ""
+}
+
+func (cb *ComboBox) matchPrefix(prefix string, start int) int {
+	if matcher, ok := cb.model.(PrefixMatcher); ok {
+		return matcher.MatchPrefix(prefix, start)
+	}
+
+	prefix = strings.ToLower(prefix)
+
+	count := cb.model.ItemCount()
+	for i := start; i < count; i++ {
+		if strings.HasPrefix(strings.ToLower(cb.itemString(i)), prefix) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// autoComplete handles a typed character and returns if it was consumed.
+func (cb *ComboBox) autoComplete(char rune) bool {
+	if cb.autoCompleteMode == AutoCompleteNone || cb.model == nil {
+		return false
+	}
+
+	now := time.Now()
+	if now.Sub(cb.autoCompleteLastKey) > comboBoxAutoCompleteTimeout {
+		cb.autoCompletePrefix = ""
+	}
+	cb.autoCompleteLastKey = now
+
+	switch {
+	case char == '\b':
+		if cb.autoCompletePrefix == "" {
+			return true
+		}
+		runes := []rune(cb.autoCompletePrefix)
+		cb.autoCompletePrefix = string(runes[:len(runes)-1])
+
+	case char < ' ':
+		return false
+
+	default:
+		cb.autoCompletePrefix += string(char)
+	}
+
+	if cb.autoCompletePrefix == "" {
+		return true
+	}
+
+	if cb.autoCompleteMode == AutoCompletePrefixDropDown {
+		cb.SendMessage(CB_SHOWDROPDOWN, TRUE, 0)
+	}
+
+	if index := cb.matchPrefix(cb.autoCompletePrefix, 0); index > -1 {
+		cb.SetCurrentIndex(index)
+	}
+
+	return true
+}
+
 func (cb *ComboBox) CurrentIndex() int {
 	return int(cb.SendMessage(CB_GETCURSEL, 0, 0))
 }
@@ -297,14 +470,68 @@ func (cb *ComboBox) SetTextSelection(start, end int) {
 
 func (cb *ComboBox) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintptr {
 	switch msg {
+	case WM_CHAR:
+		if cb.autoComplete(rune(wParam)) {
+			return 0
+		}
+
+	case WM_DRAWITEM:
+		dis := (*DRAWITEMSTRUCT)(unsafe.Pointer(lParam))
+
+		var text string
+		var font *Font
+		if cb.model != nil && int(dis.ItemID) < cb.model.ItemCount() && dis.ItemID >= 0 {
+			if etp, ok := cb.model.(EditTextProvider); ok && dis.ItemState&odsComboBoxEdit != 0 {
+				text = etp.EditText(int(dis.ItemID))
+			} else {
+				text = cb.itemString(int(dis.ItemID))
+			}
+
+			if ifp, ok := cb.model.(ItemFontProvider); ok {
+				font = ifp.ItemFont(int(dis.ItemID))
+			}
+		}
+
+		cb.drawListItem(dis, text, font)
+
+		return 1
+
+	case WM_MEASUREITEM:
+		mis := (*MEASUREITEMSTRUCT)(unsafe.Pointer(lParam))
+
+		if height := cb.textHeight(); height > 0 {
+			mis.ItemHeight = uint32(height + 2)
+		}
+
+		return 1
+
+	case WM_SETFONT:
+		result := cb.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+
+		// Owner drawn items do not adapt their height to the font by themselves.
+		// Index -1 is the selection field, 0 the items of the list.
+		if cb.ownerDrawn {
+			if height := cb.textHeight(); height > 0 {
+				cb.SendMessage(CB_SETITEMHEIGHT, ^uintptr(0), uintptr(height+2))
+				cb.SendMessage(CB_SETITEMHEIGHT, 0, uintptr(height+2))
+			}
+		}
+
+		return result
+
 	case WM_COMMAND:
 		code := HIWORD(uint32(wParam))
 		selIndex := cb.CurrentIndex()
 
 		switch code {
+		case CBN_DROPDOWN:
+			cb.updateVisibleRange()
+
 		case CBN_SELCHANGE:
 			cb.selChangeIndex = selIndex
 
+			cb.updateVisibleRange()
+
 		case CBN_SELENDCANCEL:
 			if cb.selChangeIndex != -1 {
 				cb.SetCurrentIndex(cb.selChangeIndex)
@@ -255,6 +255,27 @@ func (cb *ContainerBase) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr)
 			return widget.WndProc(hwnd, msg, wParam, lParam)
 		}
 
+	case WM_HSCROLL, WM_VSCROLL:
+		if widget := widgetFromHWND(HWND(lParam)); lParam != 0 && widget != nil {
+			// Scroll notifications, e.g. of a TrackBar, are handled by the
+			// widget that sent them.
+			return widget.WndProc(hwnd, msg, wParam, lParam)
+		}
+
+	case WM_DRAWITEM:
+		dis := (*DRAWITEMSTRUCT)(unsafe.Pointer(lParam))
+		if widget := widgetFromHWND(dis.HwndItem); widget != nil {
+			// Owner drawn widgets draw themselves.
+			return widget.WndProc(hwnd, msg, wParam, lParam)
+		}
+
+	case WM_MEASUREITEM:
+		mis := (*MEASUREITEMSTRUCT)(unsafe.Pointer(lParam))
+		// MEASUREITEMSTRUCT has no window handle, so find the widget by its id.
+		if widget := widgetFromHWND(GetDlgItem(hwnd, int32(mis.CtlID))); mis.CtlID != 0 && widget != nil {
+			return widget.WndProc(hwnd, msg, wParam, lParam)
+		}
+
 	case WM_SIZE, WM_SIZING:
 		if cb.layout != nil {
 			cb.layout.Update(false)
@@ -6,6 +6,7 @@ package walk
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 )
 
@@ -18,8 +19,10 @@ type DataBindable interface {
 }
 
 type DataBinder struct {
-	dataSource   interface{}
-	boundWidgets []DataBindable
+	dataSource                 interface{}
+	boundWidgets               []DataBindable
+	autoSubmit                 bool
+	bindingValueChangedHandles []int
 }
 
 func NewDataBinder() *DataBinder {
@@ -39,7 +42,69 @@ func (db *DataBinder) BoundWidgets() []DataBindable {
 }
 
 func (db *DataBinder) SetBoundWidgets(boundWidgets []DataBindable) {
+	db.detachBoundWidgets()
+
 	db.boundWidgets = boundWidgets
+
+	db.attachBoundWidgets()
+}
+
+// AutoSubmit returns if the *DataBinder writes a widget's value back to the
+// DataSource as soon as it changes.
+func (db *DataBinder) AutoSubmit() bool {
+	return db.autoSubmit
+}
+
+// SetAutoSubmit sets if the *DataBinder writes a widget's value back to the
+// DataSource as soon as it changes, instead of waiting for Submit.
+func (db *DataBinder) SetAutoSubmit(autoSubmit bool) {
+	if autoSubmit == db.autoSubmit {
+		return
+	}
+
+	db.detachBoundWidgets()
+
+	db.autoSubmit = autoSubmit
+
+	db.attachBoundWidgets()
+}
+
+func (db *DataBinder) attachBoundWidgets() {
+	if !db.autoSubmit {
+		return
+	}
+
+	for _, widget := range db.boundWidgets {
+		widget := widget
+
+		handle := widget.BindingValueChanged().Attach(func() {
+			db.submitWidget(widget)
+		})
+
+		db.bindingValueChangedHandles = append(db.bindingValueChangedHandles, handle)
+	}
+}
+
+func (db *DataBinder) detachBoundWidgets() {
+	for i, handle := range db.bindingValueChangedHandles {
+		db.boundWidgets[i].BindingValueChanged().Detach(handle)
+	}
+
+	db.bindingValueChangedHandles = nil
+}
+
+func (db *DataBinder) submitWidget(widget DataBindable) error {
+	s, err := db.dataSourceStruct()
+	if err != nil || !s.IsValid() {
+		return err
+	}
+
+	field := s.FieldByName(widget.BindingMember())
+	if !field.IsValid() {
+		return newError(fmt.Sprintf("Field '%s' not found in struct '%s'.", widget.BindingMember(), s.Type().Name()))
+	}
+
+	return submitField(widget, field)
 }
 
 func (db *DataBinder) Reset() error {
@@ -85,6 +150,14 @@ func (db *DataBinder) Reset() error {
 			case uintptr:
 				f64 = float64(v)
 
+			case *big.Rat:
+				// A nil *big.Rat is the zero value of the field, so treat it as 0.
+				if v == nil {
+					v = new(big.Rat)
+				}
+
+				return widget.SetBindingValue(v)
+
 			default:
 				return newError(fmt.Sprintf("Field '%s': Can't convert %s to float64.", widget.BindingMember(), field.Type().Name()))
 			}
@@ -97,51 +170,64 @@ func (db *DataBinder) Reset() error {
 }
 
 func (db *DataBinder) Submit() error {
-	return db.forEach(func(widget DataBindable, field reflect.Value) error {
-		value := widget.BindingValue()
-		if value == nil {
-			// This happens e.g. if CurrentIndex() of a ComboBox returns -1.
-			// FIXME: Should we handle this differently?
-			return nil
-		}
+	return db.forEach(submitField)
+}
 
-		if f64, ok := value.(float64); ok {
-			switch field.Kind() {
-			case reflect.Float32, reflect.Float64:
-				field.SetFloat(f64)
+func submitField(widget DataBindable, field reflect.Value) error {
+	value := widget.BindingValue()
+	if value == nil {
+		// This happens e.g. if CurrentIndex() of a ComboBox returns -1.
+		// FIXME: Should we handle this differently?
+		return nil
+	}
 
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				field.SetInt(int64(f64))
+	if f64, ok := value.(float64); ok {
+		switch field.Kind() {
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(f64)
 
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-				field.SetUint(uint64(f64))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.SetInt(int64(f64))
 
-			default:
-				return newError(fmt.Sprintf("Field '%s': Can't convert float64 to %s.", widget.BindingMember(), field.Type().Name()))
-			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			field.SetUint(uint64(f64))
 
-			return nil
+		default:
+			return newError(fmt.Sprintf("Field '%s': Can't convert float64 to %s.", widget.BindingMember(), field.Type().Name()))
 		}
 
-		field.Set(reflect.ValueOf(widget.BindingValue()))
-
 		return nil
-	})
+	}
+
+	field.Set(reflect.ValueOf(value))
+
+	return nil
 }
 
-func (db *DataBinder) forEach(f func(widget DataBindable, field reflect.Value) error) error {
+// dataSourceStruct returns the struct the DataSource points to, or an invalid
+// reflect.Value if the DataSource is a nil pointer.
+func (db *DataBinder) dataSourceStruct() (reflect.Value, error) {
 	p := reflect.ValueOf(db.dataSource)
 	if p.Type().Kind() != reflect.Ptr {
-		return newError("DataSource must be a pointer to a struct.")
+		return reflect.Value{}, newError("DataSource must be a pointer to a struct.")
 	}
 
 	if p.IsNil() {
-		return nil
+		return reflect.Value{}, nil
 	}
 
 	s := reflect.Indirect(p)
 	if s.Type().Kind() != reflect.Struct {
-		return newError("DataSource must be a pointer to a struct.")
+		return reflect.Value{}, newError("DataSource must be a pointer to a struct.")
+	}
+
+	return s, nil
+}
+
+func (db *DataBinder) forEach(f func(widget DataBindable, field reflect.Value) error) error {
+	s, err := db.dataSourceStruct()
+	if err != nil || !s.IsValid() {
+		return err
 	}
 
 	for _, widget := range db.boundWidgets {
@@ -51,7 +51,7 @@ func (cb ComboBox) Create(parent walk.Container) error {
 		w.SetFormat(cb.Format)
 		w.SetPrecision(cb.Precision)
 
-		if err := w.SetModel(cb.Model); err != nil {
+		if err := applyModel(w, cb.Model); err != nil {
 			return err
 		}
 
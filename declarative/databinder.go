@@ -11,6 +11,7 @@ import (
 type DataBinder struct {
 	AssignTo   **walk.DataBinder
 	DataSource interface{}
+	AutoSubmit bool
 }
 
 func (db DataBinder) create() (*walk.DataBinder, error) {
@@ -21,6 +22,7 @@ func (db DataBinder) create() (*walk.DataBinder, error) {
 	b := walk.NewDataBinder()
 
 	b.SetDataSource(db.DataSource)
+	b.SetAutoSubmit(db.AutoSubmit)
 
 	if db.AssignTo != nil {
 		*db.AssignTo = b
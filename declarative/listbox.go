@@ -39,7 +39,7 @@ func (lb ListBox) Create(parent walk.Container) error {
 		w.SetFormat(lb.Format)
 		w.SetPrecision(lb.Precision)
 
-		if err := w.SetModel(lb.Model); err != nil {
+		if err := applyModel(w, lb.Model); err != nil {
 			return err
 		}
 
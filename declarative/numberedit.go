@@ -4,6 +4,11 @@
 
 package declarative
 
+import (
+	"errors"
+	"fmt"
+)
+
 import (
 	"github.com/lxn/walk"
 )
@@ -27,8 +32,8 @@ type NumberEdit struct {
 	Increment          float64
 	MinValue           float64
 	MaxValue           float64
-	Value              float64
-	OnValueChanged     walk.EventHandler
+	Value              interface{}
+	OnValueChanged     walk.Float64EventHandler
 }
 
 func (ne NumberEdit) Create(parent walk.Container) error {
@@ -61,12 +66,35 @@ func (ne NumberEdit) Create(parent walk.Container) error {
 			}
 		}
 
-		if err := w.SetValue(ne.Value); err != nil {
-			return err
+		// Value is either a constant or the binding member to bind to.
+		switch v := ne.Value.(type) {
+		case nil:
+
+		case float64:
+			if err := w.SetValue(v); err != nil {
+				return err
+			}
+
+		case int:
+			if err := w.SetValue(float64(v)); err != nil {
+				return err
+			}
+
+		case string:
+			if ne.BindTo != "" {
+				return errors.New("declarative.NumberEdit: Value must not be a binding expression if BindTo is set.")
+			}
+
+			if err := w.SetBindingMember(v); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("declarative.NumberEdit: Unsupported Value type %T.", v)
 		}
 
 		if ne.OnValueChanged != nil {
-			w.ValueChanged().Attach(ne.OnValueChanged)
+			w.ValueChangedF().Attach(ne.OnValueChanged)
 		}
 
 		if ne.AssignTo != nil {
@@ -23,6 +23,7 @@ type PushButton struct {
 	ColumnSpan         int
 	ContextMenuActions []*walk.Action
 	Text               string
+	Default            bool
 	OnClicked          walk.EventHandler
 }
 
@@ -37,6 +38,18 @@ func (pb PushButton) Create(parent walk.Container) error {
 			return err
 		}
 
+		if pb.Default {
+			// Make w the default button of the dialog it lives in, if any.
+			for c := parent; c != nil; c = c.Parent() {
+				if dlg, ok := c.(*walk.Dialog); ok {
+					if err := dlg.SetDefaultButton(w); err != nil {
+						return err
+					}
+					break
+				}
+			}
+		}
+
 		if pb.OnClicked != nil {
 			w.Clicked().Attach(pb.OnClicked)
 		}
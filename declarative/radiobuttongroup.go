@@ -0,0 +1,66 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package declarative
+
+import (
+	"github.com/lxn/walk"
+)
+
+// RadioButtonGroup creates its Buttons in the parent and groups them, see
+// walk.RadioButtonGroup. The group itself is not a widget, so the buttons are
+// laid out by the parent like any other children.
+//
+// If CurrentIndex is not nil, the button at *CurrentIndex is checked initially
+// and *CurrentIndex is updated whenever another button gets checked.
+type RadioButtonGroup struct {
+	AssignTo              **walk.RadioButtonGroup
+	Buttons               []RadioButton
+	CurrentIndex          *int
+	OnCurrentIndexChanged walk.EventHandler
+}
+
+func (rbg RadioButtonGroup) Create(parent walk.Container) error {
+	g := walk.NewRadioButtonGroup()
+
+	for _, rb := range rbg.Buttons {
+		var w *walk.RadioButton
+		if rb.AssignTo == nil {
+			rb.AssignTo = &w
+		}
+
+		if err := rb.Create(parent); err != nil {
+			return err
+		}
+
+		if err := g.Add(*rb.AssignTo); err != nil {
+			return err
+		}
+	}
+
+	if rbg.CurrentIndex != nil {
+		if err := g.SetCurrentIndex(*rbg.CurrentIndex); err != nil {
+			return err
+		}
+
+		currentIndex := rbg.CurrentIndex
+		g.CurrentIndexChanged().Attach(func() {
+			*currentIndex = g.CurrentIndex()
+		})
+	}
+
+	if rbg.OnCurrentIndexChanged != nil {
+		g.CurrentIndexChanged().Attach(rbg.OnCurrentIndexChanged)
+	}
+
+	if rbg.AssignTo != nil {
+		*rbg.AssignTo = g
+	}
+
+	return nil
+}
+
+func (rbg RadioButtonGroup) WidgetInfo() (name string, disabled, hidden bool, font *Font, minSize, maxSize Size, stretchFactor, row, rowSpan, column, columnSpan int, contextMenuActions []*walk.Action) {
+	return "", false, false, nil, Size{}, Size{}, 0, 0, 0, 0, 0, nil
+}
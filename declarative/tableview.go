@@ -41,7 +41,7 @@ func (tv TableView) Create(parent walk.Container) error {
 	}
 
 	return InitWidget(tv, w, func() error {
-		if err := w.SetModel(tv.Model); err != nil {
+		if err := applyModel(w, tv.Model); err != nil {
 			return err
 		}
 
@@ -0,0 +1,44 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package declarative
+
+import (
+	"github.com/lxn/walk"
+)
+
+// TableViewColumn declares a column of a table, see walk.TableColumn.
+type TableViewColumn struct {
+	Name      string
+	Title     string
+	Format    string
+	Width     int
+	Alignment walk.Alignment1D
+	Hidden    bool
+}
+
+func (tvc TableViewColumn) toW() walk.TableColumn {
+	return walk.TableColumn{
+		Name:      tvc.Name,
+		Title:     tvc.Title,
+		Format:    tvc.Format,
+		Width:     tvc.Width,
+		Alignment: tvc.Alignment,
+		Hidden:    tvc.Hidden,
+	}
+}
+
+// TableColumns returns the walk.TableColumn values for columns.
+//
+// A TableView gets its columns from its model, so declare the columns once and
+// pass the result to the models of all views that share them, e.g. to
+// walk.NewSliceTableModel.
+func TableColumns(columns []TableViewColumn) []walk.TableColumn {
+	wcs := make([]walk.TableColumn, len(columns))
+	for i, column := range columns {
+		wcs[i] = column.toW()
+	}
+
+	return wcs
+}
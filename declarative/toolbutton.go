@@ -22,6 +22,7 @@ type ToolButton struct {
 	Column             int
 	ColumnSpan         int
 	ContextMenuActions []*walk.Action
+	Action             *walk.Action
 	Text               string
 	OnClicked          walk.EventHandler
 }
@@ -33,12 +34,19 @@ func (tb ToolButton) Create(parent walk.Container) error {
 	}
 
 	return InitWidget(tb, w, func() error {
-		if err := w.SetText(tb.Text); err != nil {
-			return err
-		}
+		if tb.Action != nil {
+			// The action provides text and click handling.
+			if err := w.SetAction(tb.Action); err != nil {
+				return err
+			}
+		} else {
+			if err := w.SetText(tb.Text); err != nil {
+				return err
+			}
 
-		if tb.OnClicked != nil {
-			w.Clicked().Attach(tb.OnClicked)
+			if tb.OnClicked != nil {
+				w.Clicked().Attach(tb.OnClicked)
+			}
 		}
 
 		if tb.AssignTo != nil {
@@ -50,5 +58,12 @@ func (tb ToolButton) Create(parent walk.Container) error {
 }
 
 func (tb ToolButton) WidgetInfo() (name string, disabled, hidden bool, font *Font, minSize, maxSize Size, stretchFactor, row, rowSpan, column, columnSpan int, contextMenuActions []*walk.Action) {
-	return tb.Name, tb.Disabled, tb.Hidden, &tb.Font, tb.MinSize, tb.MaxSize, tb.StretchFactor, tb.Row, tb.RowSpan, tb.Column, tb.ColumnSpan, tb.ContextMenuActions
+	disabled, hidden = tb.Disabled, tb.Hidden
+	if tb.Action != nil {
+		// Don't let InitWidget override the state of the action.
+		disabled = disabled || !tb.Action.Enabled()
+		hidden = hidden || !tb.Action.Visible()
+	}
+
+	return tb.Name, disabled, hidden, &tb.Font, tb.MinSize, tb.MaxSize, tb.StretchFactor, tb.Row, tb.RowSpan, tb.Column, tb.ColumnSpan, tb.ContextMenuActions
 }
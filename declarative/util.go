@@ -4,6 +4,10 @@
 
 package declarative
 
+import (
+	"fmt"
+)
+
 import (
 	"github.com/lxn/walk"
 )
@@ -120,3 +124,41 @@ func InitWidget(d Widget, w walk.Widget, customInit func() error) error {
 
 	return nil
 }
+
+// applyModel sets model as the model of the model based widget w.
+//
+// A nil model is passed on to w, so the widget is reset to have no model.
+func applyModel(w walk.Widget, model interface{}) error {
+	switch w := w.(type) {
+	case *walk.ComboBox:
+		lm, ok := model.(walk.ListModel)
+		if !ok && model != nil {
+			return modelTypeError(w, model)
+		}
+		return w.SetModel(lm)
+
+	case *walk.ListBox:
+		lm, ok := model.(walk.ListModel)
+		if !ok && model != nil {
+			return modelTypeError(w, model)
+		}
+		return w.SetModel(lm)
+
+	case *walk.TableView:
+		tm, ok := model.(walk.TableModel)
+		if !ok && model != nil {
+			return modelTypeError(w, model)
+		}
+		return w.SetModel(tm)
+	}
+
+	if model != nil {
+		return fmt.Errorf("declarative: %T does not support models", w)
+	}
+
+	return nil
+}
+
+func modelTypeError(w walk.Widget, model interface{}) error {
+	return fmt.Errorf("declarative: %T is not a supported model for %T", model, w)
+}
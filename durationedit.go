@@ -0,0 +1,277 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+import . "github.com/lxn/go-winapi"
+
+const durationEditWindowClass = `\o/ Walk_DurationEdit_Class \o/`
+
+func init() {
+	MustRegisterWindowClass(durationEditWindowClass)
+}
+
+// DurationEdit is a widget for entering a duration as HH:MM:SS, with its value
+// in seconds.
+//
+// The spinner and the Up and Down arrow keys step the field the caret is in.
+type DurationEdit struct {
+	WidgetBase
+	edit                  *LineEdit
+	hWndUpDown            HWND
+	bindingMember         string
+	oldValue              float64
+	valueChangedPublisher EventPublisher
+}
+
+func NewDurationEdit(parent Container) (*DurationEdit, error) {
+	de := &DurationEdit{}
+
+	if err := InitChildWidget(
+		de,
+		parent,
+		durationEditWindowClass,
+		WS_VISIBLE,
+		WS_EX_CONTROLPARENT); err != nil {
+		return nil, err
+	}
+
+	var succeeded bool
+	defer func() {
+		if !succeeded {
+			de.Dispose()
+		}
+	}()
+
+	var err error
+	de.edit, err = newLineEdit(de)
+	if err != nil {
+		return nil, err
+	}
+	if err = de.edit.setAndClearStyleBits(ES_RIGHT, ES_LEFT|ES_CENTER); err != nil {
+		return nil, err
+	}
+
+	de.hWndUpDown = CreateWindowEx(
+		0, syscall.StringToUTF16Ptr("msctls_updown32"), nil,
+		WS_CHILD|WS_VISIBLE|UDS_ALIGNRIGHT|UDS_HOTTRACK,
+		0, 0, 16, 20, de.hWnd, 0, 0, nil)
+	if de.hWndUpDown == 0 {
+		return nil, lastError("CreateWindowEx")
+	}
+
+	SendMessage(de.hWndUpDown, UDM_SETBUDDY, uintptr(de.edit.hWnd), 0)
+
+	de.edit.KeyDown().Attach(func(key int) {
+		switch key {
+		case VK_UP:
+			de.step(1)
+
+		case VK_DOWN:
+			de.step(-1)
+		}
+	})
+
+	if err = de.SetValue(0); err != nil {
+		return nil, err
+	}
+
+	succeeded = true
+
+	return de, nil
+}
+
+func (de *DurationEdit) Enabled() bool {
+	return de.WidgetBase.Enabled()
+}
+
+func (de *DurationEdit) SetEnabled(value bool) {
+	de.edit.SetEnabled(value)
+	de.WidgetBase.SetEnabled(value)
+}
+
+func (de *DurationEdit) Font() *Font {
+	var f *Font
+	if de.edit != nil {
+		f = de.font
+	}
+
+	if f != nil {
+		return f
+	} else if de.parent != nil {
+		return de.parent.Font()
+	}
+
+	return defaultFont
+}
+
+func (de *DurationEdit) SetFont(value *Font) {
+	de.edit.SetFont(value)
+}
+
+func (*DurationEdit) LayoutFlags() LayoutFlags {
+	return ShrinkableHorz | GrowableHorz
+}
+
+func (de *DurationEdit) MinSizeHint() Size {
+	return de.dialogBaseUnitsToPixels(Size{30, 12})
+}
+
+func (de *DurationEdit) SizeHint() Size {
+	s := de.dialogBaseUnitsToPixels(Size{50, 12})
+	return Size{s.Width, maxi(s.Height, 22)}
+}
+
+func (de *DurationEdit) BindingMember() string {
+	return de.bindingMember
+}
+
+func (de *DurationEdit) SetBindingMember(member string) error {
+	if err := validateBindingMemberSyntax(member); err != nil {
+		return err
+	}
+
+	de.bindingMember = member
+
+	return nil
+}
+
+func (de *DurationEdit) BindingValue() interface{} {
+	return de.Value()
+}
+
+func (de *DurationEdit) SetBindingValue(value interface{}) error {
+	return de.SetValue(value.(float64))
+}
+
+func (de *DurationEdit) BindingValueChanged() *Event {
+	return de.ValueChanged()
+}
+
+// Value returns the duration in seconds, or 0 if the text is not a valid
+// duration.
+func (de *DurationEdit) Value() float64 {
+	val, _ := parseDuration(de.edit.Text())
+	return val
+}
+
+// SetValue sets the duration in seconds, which is rounded to whole seconds.
+func (de *DurationEdit) SetValue(value float64) error {
+	return de.edit.SetText(formatDuration(value))
+}
+
+func (de *DurationEdit) ValueChanged() *Event {
+	return de.valueChangedPublisher.Event()
+}
+
+func (de *DurationEdit) SetFocus() error {
+	if SetFocus(de.edit.hWnd) == 0 {
+		return lastError("SetFocus")
+	}
+
+	return nil
+}
+
+// step increases the field the caret is in by steps, or decreases it for
+// negative steps, keeping the caret in the field.
+func (de *DurationEdit) step(steps int) {
+	text := de.edit.Text()
+	caret, _ := de.edit.TextSelection()
+
+	utf16 := syscall.StringToUTF16(text)
+	if caret > len(utf16)-1 {
+		caret = len(utf16) - 1
+	}
+
+	fields := strings.Count(text, ":") + 1
+	field := strings.Count(syscall.UTF16ToString(utf16[:caret]), ":")
+
+	unit := math.Pow(60, float64(fields-1-field))
+
+	fromEnd := len(utf16) - caret
+
+	de.SetValue(math.Max(0, de.Value()+float64(steps)*unit))
+
+	caret = len(syscall.StringToUTF16(de.edit.Text())) - fromEnd
+	if caret < 0 {
+		caret = 0
+	}
+	de.edit.SetTextSelection(caret, caret)
+}
+
+func (de *DurationEdit) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	if de.hWndUpDown != 0 {
+		switch msg {
+		case WM_COMMAND:
+			switch HIWORD(uint32(wParam)) {
+			case EN_CHANGE:
+				value := de.Value()
+				if value == de.oldValue {
+					break
+				}
+
+				de.oldValue = value
+
+				de.valueChangedPublisher.Publish()
+			}
+
+		case WM_NOTIFY:
+			switch ((*NMHDR)(unsafe.Pointer(lParam))).Code {
+			case UDN_DELTAPOS:
+				nmud := (*NMUPDOWN)(unsafe.Pointer(lParam))
+				// Negative deltas come from the up arrow.
+				de.step(-int(nmud.IDelta))
+			}
+
+		case WM_SIZE, WM_SIZING:
+			cb := de.ClientBounds()
+			if err := de.edit.SetBounds(cb); err != nil {
+				break
+			}
+			SendMessage(de.hWndUpDown, UDM_SETBUDDY, uintptr(de.edit.hWnd), 0)
+		}
+	}
+
+	return de.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+}
+
+// formatDuration formats seconds as HH:MM:SS.
+func formatDuration(seconds float64) string {
+	s := int64(math.Floor(math.Max(0, seconds) + 0.5))
+
+	return fmt.Sprintf("%02d:%02d:%02d", s/3600, s/60%60, s%60)
+}
+
+var errInvalidDuration = errors.New("invalid duration")
+
+// parseDuration parses a duration given as HH:MM:SS, MM:SS or SS and returns it
+// in seconds.
+func parseDuration(s string) (float64, error) {
+	fields := strings.Split(strings.TrimSpace(s), ":")
+	if len(fields) > 3 {
+		return 0, errInvalidDuration
+	}
+
+	var seconds float64
+	for _, field := range fields {
+		n, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return 0, errInvalidDuration
+		}
+
+		seconds = seconds*60 + float64(n)
+	}
+
+	return seconds, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"sort"
+)
+
+// FilteredTableModel is a TableModel that wraps another TableModel and only
+// presents the rows of it that match a predicate.
+type FilteredTableModel struct {
+	TableModelBase
+	inner                     TableModel
+	predicate                 func(row int) bool
+	rows                      []int
+	rowsResetHandlerHandle    int
+	rowChangedHandlerHandle   int
+	columnsResetHandlerHandle int
+}
+
+// NewFilteredTableModel returns a new *FilteredTableModel that presents the
+// rows of inner for which predicate returns true. The predicate is called with
+// row indexes of inner. A nil predicate matches all rows.
+func NewFilteredTableModel(inner TableModel, predicate func(row int) bool) *FilteredTableModel {
+	m := &FilteredTableModel{inner: inner, predicate: predicate}
+
+	m.filter()
+
+	m.rowsResetHandlerHandle = inner.RowsReset().Attach(func() {
+		m.filter()
+		m.PublishRowsReset()
+	})
+
+	m.rowChangedHandlerHandle = inner.RowChanged().Attach(func(row int) {
+		i := sort.SearchInts(m.rows, row)
+		included := i < len(m.rows) && m.rows[i] == row
+
+		if included != m.matches(row) {
+			m.filter()
+			m.PublishRowsReset()
+		} else if included {
+			m.PublishRowChanged(i)
+		}
+	})
+
+	m.columnsResetHandlerHandle = inner.ColumnsReset().Attach(func() {
+		m.PublishColumnsReset()
+	})
+
+	return m
+}
+
+// Dispose detaches the *FilteredTableModel from the events of the inner model.
+func (m *FilteredTableModel) Dispose() {
+	m.inner.RowsReset().Detach(m.rowsResetHandlerHandle)
+	m.inner.RowChanged().Detach(m.rowChangedHandlerHandle)
+	m.inner.ColumnsReset().Detach(m.columnsResetHandlerHandle)
+}
+
+// Inner returns the wrapped TableModel.
+func (m *FilteredTableModel) Inner() TableModel {
+	return m.inner
+}
+
+// SetPredicate sets the predicate that decides which rows of the inner model
+// are presented, filters the rows again and publishes the RowsReset event.
+func (m *FilteredTableModel) SetPredicate(predicate func(row int) bool) {
+	m.predicate = predicate
+
+	m.filter()
+
+	m.PublishRowsReset()
+}
+
+// InnerRow returns the index in the inner model of the row at index row.
+func (m *FilteredTableModel) InnerRow(row int) int {
+	return m.rows[row]
+}
+
+func (m *FilteredTableModel) matches(row int) bool {
+	return m.predicate == nil || m.predicate(row)
+}
+
+func (m *FilteredTableModel) filter() {
+	m.rows = m.rows[:0]
+
+	count := m.inner.RowCount()
+	for row := 0; row < count; row++ {
+		if m.matches(row) {
+			m.rows = append(m.rows, row)
+		}
+	}
+}
+
+func (m *FilteredTableModel) Columns() []TableColumn {
+	return m.inner.Columns()
+}
+
+func (m *FilteredTableModel) RowCount() int {
+	return len(m.rows)
+}
+
+func (m *FilteredTableModel) Value(row, col int) interface{} {
+	return m.inner.Value(m.rows[row], col)
+}
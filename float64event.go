@@ -0,0 +1,43 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+type Float64EventHandler func(value float64)
+
+type Float64Event struct {
+	handlers []Float64EventHandler
+}
+
+func (e *Float64Event) Attach(handler Float64EventHandler) int {
+	for i, h := range e.handlers {
+		if h == nil {
+			e.handlers[i] = handler
+			return i
+		}
+	}
+
+	e.handlers = append(e.handlers, handler)
+	return len(e.handlers) - 1
+}
+
+func (e *Float64Event) Detach(handle int) {
+	e.handlers[handle] = nil
+}
+
+type Float64EventPublisher struct {
+	event Float64Event
+}
+
+func (p *Float64EventPublisher) Event() *Float64Event {
+	return &p.event
+}
+
+func (p *Float64EventPublisher) Publish(value float64) {
+	for _, handler := range p.event.handlers {
+		if handler != nil {
+			handler(value)
+		}
+	}
+}
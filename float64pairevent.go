@@ -0,0 +1,43 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+type Float64PairEventHandler func(a, b float64)
+
+type Float64PairEvent struct {
+	handlers []Float64PairEventHandler
+}
+
+func (e *Float64PairEvent) Attach(handler Float64PairEventHandler) int {
+	for i, h := range e.handlers {
+		if h == nil {
+			e.handlers[i] = handler
+			return i
+		}
+	}
+
+	e.handlers = append(e.handlers, handler)
+	return len(e.handlers) - 1
+}
+
+func (e *Float64PairEvent) Detach(handle int) {
+	e.handlers[handle] = nil
+}
+
+type Float64PairEventPublisher struct {
+	event Float64PairEvent
+}
+
+func (p *Float64PairEventPublisher) Event() *Float64PairEvent {
+	return &p.event
+}
+
+func (p *Float64PairEventPublisher) Publish(a, b float64) {
+	for _, handler := range p.event.handlers {
+		if handler != nil {
+			handler(a, b)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+// FooterTableModel is a TableModel that wraps another TableModel and appends a
+// footer row of aggregates, e.g. totals.
+//
+// A view like TableView treats the footer as a normal additional row, e.g. it
+// can be selected like any other row.
+type FooterTableModel struct {
+	TableModelBase
+	inner                     TableModel
+	agg                       func(col int) interface{}
+	rowsResetHandlerHandle    int
+	rowChangedHandlerHandle   int
+	columnsResetHandlerHandle int
+}
+
+// NewFooterTableModel returns a new *FooterTableModel that presents the rows of
+// inner, followed by a row with the values returned from agg for each column.
+func NewFooterTableModel(inner TableModel, agg func(col int) interface{}) *FooterTableModel {
+	m := &FooterTableModel{inner: inner, agg: agg}
+
+	m.rowsResetHandlerHandle = inner.RowsReset().Attach(func() {
+		m.PublishRowsReset()
+	})
+
+	m.rowChangedHandlerHandle = inner.RowChanged().Attach(func(row int) {
+		m.PublishRowChanged(row)
+
+		// The aggregates may depend on the changed row.
+		m.PublishRowChanged(m.FooterRow())
+	})
+
+	m.columnsResetHandlerHandle = inner.ColumnsReset().Attach(func() {
+		m.PublishColumnsReset()
+	})
+
+	return m
+}
+
+// Dispose detaches the *FooterTableModel from the events of the inner model.
+func (m *FooterTableModel) Dispose() {
+	m.inner.RowsReset().Detach(m.rowsResetHandlerHandle)
+	m.inner.RowChanged().Detach(m.rowChangedHandlerHandle)
+	m.inner.ColumnsReset().Detach(m.columnsResetHandlerHandle)
+}
+
+// Inner returns the wrapped TableModel.
+func (m *FooterTableModel) Inner() TableModel {
+	return m.inner
+}
+
+// FooterRow returns the index of the footer row.
+func (m *FooterTableModel) FooterRow() int {
+	return m.inner.RowCount()
+}
+
+func (m *FooterTableModel) Columns() []TableColumn {
+	return m.inner.Columns()
+}
+
+func (m *FooterTableModel) RowCount() int {
+	return m.inner.RowCount() + 1
+}
+
+func (m *FooterTableModel) Value(row, col int) interface{} {
+	if row == m.FooterRow() {
+		return m.agg(col)
+	}
+
+	return m.inner.Value(row, col)
+}
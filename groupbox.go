@@ -141,7 +141,7 @@ func (gb *GroupBox) SetLayout(value Layout) error {
 func (gb *GroupBox) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintptr {
 	if gb.composite != nil {
 		switch msg {
-		case WM_COMMAND, WM_NOTIFY:
+		case WM_COMMAND, WM_NOTIFY, WM_DRAWITEM:
 			gb.composite.WndProc(hwnd, msg, wParam, lParam)
 
 		case WM_SIZE, WM_SIZING:
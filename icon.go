@@ -7,6 +7,7 @@ package walk
 import (
 	"path/filepath"
 	"syscall"
+	"unsafe"
 )
 
 import . "github.com/lxn/go-winapi"
@@ -67,3 +68,43 @@ func (i *Icon) Dispose() error {
 
 	return nil
 }
+
+// copyHandle returns a copy of the icon handle, that the caller must destroy.
+func (i *Icon) copyHandle() (HICON, error) {
+	hIcon, _, _ := copyIcon.Call(uintptr(i.hIcon))
+	if hIcon == 0 {
+		return 0, lastError("CopyIcon")
+	}
+
+	return HICON(hIcon), nil
+}
+
+// size returns the size of the icon in pixels.
+func (i *Icon) size() (Size, error) {
+	var ii ICONINFO
+	if !GetIconInfo(i.hIcon, &ii) {
+		return Size{}, lastError("GetIconInfo")
+	}
+	defer DeleteObject(HGDIOBJ(ii.HbmMask))
+	if ii.HbmColor != 0 {
+		defer DeleteObject(HGDIOBJ(ii.HbmColor))
+	}
+
+	hBmp := ii.HbmColor
+	if hBmp == 0 {
+		hBmp = ii.HbmMask
+	}
+
+	var bmp BITMAP
+	if GetObject(HGDIOBJ(hBmp), unsafe.Sizeof(bmp), unsafe.Pointer(&bmp)) == 0 {
+		return Size{}, newError("GetObject failed")
+	}
+
+	size := Size{int(bmp.BmWidth), int(bmp.BmHeight)}
+	if ii.HbmColor == 0 {
+		// A monochrome icon stacks the AND and XOR masks vertically.
+		size.Height /= 2
+	}
+
+	return size, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+// KeyedCheckerBase implements the ItemChecker interface by storing the keys of
+// the checked items, as returned by a RowKeyProvider, instead of their indexes.
+//
+// This way, the same logical items stay checked after the rows of the model were
+// sorted or filtered. Call SetRowKeyProvider, usually with the model itself,
+// before using it.
+type KeyedCheckerBase struct {
+	keyProvider RowKeyProvider
+	checked     map[interface{}]bool
+}
+
+// SetRowKeyProvider sets the RowKeyProvider, that maps item indexes to keys.
+func (kcb *KeyedCheckerBase) SetRowKeyProvider(provider RowKeyProvider) {
+	kcb.keyProvider = provider
+}
+
+func (kcb *KeyedCheckerBase) Checked(index int) bool {
+	if kcb.keyProvider == nil {
+		return false
+	}
+
+	return kcb.checked[kcb.keyProvider.RowKey(index)]
+}
+
+func (kcb *KeyedCheckerBase) SetChecked(index int, checked bool) error {
+	if kcb.keyProvider == nil {
+		return newError("no RowKeyProvider set")
+	}
+
+	kcb.SetKeyChecked(kcb.keyProvider.RowKey(index), checked)
+
+	return nil
+}
+
+// KeyChecked returns if the item with the specified key is checked.
+func (kcb *KeyedCheckerBase) KeyChecked(key interface{}) bool {
+	return kcb.checked[key]
+}
+
+// SetKeyChecked sets if the item with the specified key is checked. The item
+// does not need to be visible currently, e.g. because it is filtered out.
+func (kcb *KeyedCheckerBase) SetKeyChecked(key interface{}, checked bool) {
+	if !checked {
+		delete(kcb.checked, key)
+		return
+	}
+
+	if kcb.checked == nil {
+		kcb.checked = make(map[interface{}]bool)
+	}
+
+	kcb.checked[key] = true
+}
+
+// CheckedKeys returns the keys of all checked items, in no particular order.
+func (kcb *KeyedCheckerBase) CheckedKeys() []interface{} {
+	keys := make([]interface{}, 0, len(kcb.checked))
+
+	for key := range kcb.checked {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// ClearChecked unchecks all items.
+func (kcb *KeyedCheckerBase) ClearChecked() {
+	kcb.checked = nil
+}
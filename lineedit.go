@@ -16,15 +16,31 @@ const (
 	lineEditGreedyLimit = 80 // fields with MaxLength larger than this will be greedy (default length is 32767)
 )
 
+// go-winapi does not define the balloon tip messages of EDIT controls yet.
+const (
+	emShowBalloonTip = 0x1503
+	emHideBalloonTip = 0x1504
+)
+
+type editBalloonTip struct {
+	cbStruct uint32
+	pszTitle *uint16
+	pszText  *uint16
+	ttiIcon  int32
+}
+
 type LineEdit struct {
 	WidgetBase
-	bindingMember            string
-	validator                Validator
-	editingFinishedPublisher EventPublisher
-	returnPressedPublisher   EventPublisher
-	textChangedPublisher     EventPublisher
-	charWidthFont            *Font
-	charWidth                int
+	bindingMember                 string
+	validator                     Validator
+	editingFinishedPublisher      EventPublisher
+	returnPressedPublisher        EventPublisher
+	textChangedPublisher          EventPublisher
+	textSelectionChangedPublisher EventPublisher
+	selStart                      int
+	selEnd                        int
+	charWidthFont                 *Font
+	charWidth                     int
 }
 
 func newLineEdit(parent Widget) (*LineEdit, error) {
@@ -129,6 +145,23 @@ func (le *LineEdit) SetText(value string) error {
 	return setWidgetText(le.hWnd, value)
 }
 
+// showBalloonTip shows a balloon tip with an error icon next to the
+// *LineEdit. The icon is only shown if title is not empty.
+func (le *LineEdit) showBalloonTip(title, text string) {
+	ebt := editBalloonTip{
+		pszTitle: syscall.StringToUTF16Ptr(title),
+		pszText:  syscall.StringToUTF16Ptr(text),
+		ttiIcon:  TTI_ERROR,
+	}
+	ebt.cbStruct = uint32(unsafe.Sizeof(ebt))
+
+	le.SendMessage(emShowBalloonTip, 0, uintptr(unsafe.Pointer(&ebt)))
+}
+
+func (le *LineEdit) hideBalloonTip() {
+	le.SendMessage(emHideBalloonTip, 0, 0)
+}
+
 func (le *LineEdit) TextSelection() (start, end int) {
 	le.SendMessage(EM_GETSEL, uintptr(unsafe.Pointer(&start)), uintptr(unsafe.Pointer(&end)))
 	return
@@ -238,6 +271,23 @@ func (le *LineEdit) TextChanged() *Event {
 	return le.textChangedPublisher.Event()
 }
 
+func (le *LineEdit) TextSelectionChanged() *Event {
+	return le.textSelectionChangedPublisher.Event()
+}
+
+// An EDIT control does not send EN_SELCHANGE (only rich edit controls do), so
+// we compare the selection after messages that may have changed it.
+func (le *LineEdit) checkTextSelectionChanged() {
+	start, end := le.TextSelection()
+	if start == le.selStart && end == le.selEnd {
+		return
+	}
+
+	le.selStart, le.selEnd = start, end
+
+	le.textSelectionChangedPublisher.Publish()
+}
+
 func (le *LineEdit) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintptr {
 	switch msg {
 	/*	case WM_CHAR:
@@ -285,5 +335,12 @@ func (le *LineEdit) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintp
 		le.editingFinishedPublisher.Publish()
 	}
 
-	return le.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+	result := le.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+
+	switch msg {
+	case WM_KEYDOWN, WM_CHAR, WM_LBUTTONDOWN, WM_LBUTTONUP, WM_MOUSEMOVE, WM_SETFOCUS, WM_SETTEXT, EM_SETSEL:
+		le.checkTextSelectionChanged()
+	}
+
+	return result
 }
@@ -22,7 +22,9 @@ type ListBox struct {
 	prevCurIndex                 int
 	itemsResetHandlerHandle      int
 	itemChangedHandlerHandle     int
+	itemsChangedHandlerHandle    int
 	maxItemTextWidth             int
+	ownerDrawn                   bool
 	currentIndexChangedPublisher EventPublisher
 	dblClickedPublisher          EventPublisher
 }
@@ -33,11 +35,15 @@ func NewListBox(parent Container) (*ListBox, error) {
 		lb,
 		parent,
 		"LISTBOX",
-		WS_TABSTOP|WS_VISIBLE|LBS_STANDARD,
+		WS_TABSTOP|WS_VISIBLE|LBS_STANDARD|LBS_HASSTRINGS,
 		0)
 	if err != nil {
 		return nil, err
 	}
+
+	// The parent finds us by id to forward WM_MEASUREITEM.
+	SetWindowLong(lb.hWnd, GWLP_ID, newCtrlID())
+
 	return lb, nil
 }
 
@@ -89,6 +95,10 @@ func (lb *ListBox) resetItems() error {
 		return nil
 	}
 
+	// A LazyListModel must know the visible range before the items are
+	// inserted, or it fetches every single one of them.
+	lb.updateVisibleRange()
+
 	count := lb.model.ItemCount()
 
 	for i := 0; i < count; i++ {
@@ -100,27 +110,77 @@ func (lb *ListBox) resetItems() error {
 	return nil
 }
 
+// tell a LazyListModel which items are currently visible
+func (lb *ListBox) updateVisibleRange() {
+	lazy, ok := lb.model.(LazyListModel)
+	if !ok {
+		return
+	}
+
+	itemHeight := int(lb.SendMessage(LB_GETITEMHEIGHT, 0, 0))
+	if itemHeight <= 0 {
+		return
+	}
+
+	top := int(lb.SendMessage(LB_GETTOPINDEX, 0, 0))
+	count := lb.ClientBounds().Height/itemHeight + 1
+
+	lazy.SetVisibleRange(top, count)
+}
+
+// itemHeight returns the height of the item at index, as provided by an
+// ItemSizeProvider model, or the height of a line of text.
+func (lb *ListBox) itemHeight(index int) int {
+	if isp, ok := lb.model.(ItemSizeProvider); ok && index >= 0 && index < lb.model.ItemCount() {
+		if height := isp.ItemSize(index).Height; height > 0 {
+			return height
+		}
+	}
+
+	return lb.textHeight()
+}
+
 func (lb *ListBox) attachModel() {
 	itemsResetHandler := func() {
 		lb.resetItems()
 	}
 	lb.itemsResetHandlerHandle = lb.model.ItemsReset().Attach(itemsResetHandler)
 
-	itemChangedHandler := func(index int) {
-		if CB_ERR == lb.SendMessage(LB_DELETESTRING, uintptr(index), 0) {
-			newError("SendMessage(CB_DELETESTRING)")
-		}
+	itemsChangedHandler := func(from, to int) {
+		// Redraw once for the whole block instead of once per item.
+		lb.SetSuspended(true)
+		defer func() {
+			lb.SetSuspended(false)
+			lb.Invalidate()
+		}()
+
+		for index := from; index <= to; index++ {
+			if CB_ERR == lb.SendMessage(LB_DELETESTRING, uintptr(index), 0) {
+				newError("SendMessage(CB_DELETESTRING)")
+			}
 
-		lb.insertItemAt(index)
+			lb.insertItemAt(index)
+		}
 
 		lb.SetCurrentIndex(lb.prevCurIndex)
 	}
-	lb.itemChangedHandlerHandle = lb.model.ItemChanged().Attach(itemChangedHandler)
+
+	if icn, ok := lb.model.(ItemsChangedNotifier); ok {
+		lb.itemsChangedHandlerHandle = icn.ItemsChanged().Attach(itemsChangedHandler)
+	} else {
+		lb.itemChangedHandlerHandle = lb.model.ItemChanged().Attach(func(index int) {
+			itemsChangedHandler(index, index)
+		})
+	}
 }
 
 func (lb *ListBox) detachModel() {
 	lb.model.ItemsReset().Detach(lb.itemsResetHandlerHandle)
-	lb.model.ItemChanged().Detach(lb.itemChangedHandlerHandle)
+	if icn, ok := lb.model.(ItemsChangedNotifier); ok {
+		icn.ItemsChanged().Detach(lb.itemsChangedHandlerHandle)
+	} else {
+		lb.model.ItemChanged().Detach(lb.itemChangedHandlerHandle)
+	}
 }
 
 func (lb *ListBox) Model() ListModel {
@@ -134,6 +194,10 @@ func (lb *ListBox) SetModel(model ListModel) error {
 
 	lb.model = model
 
+	if err := lb.applyOwnerDraw(); err != nil {
+		return err
+	}
+
 	if model != nil {
 		lb.attachModel()
 
@@ -143,6 +207,35 @@ func (lb *ListBox) SetModel(model ListModel) error {
 	return nil
 }
 
+// applyOwnerDraw makes the *ListBox owner drawn if its model implements
+// ItemFontProvider or ItemSizeProvider, and system drawn otherwise.
+func (lb *ListBox) applyOwnerDraw() error {
+	_, hasItemFonts := lb.model.(ItemFontProvider)
+	_, hasItemSizes := lb.model.(ItemSizeProvider)
+
+	ownerDrawn := hasItemFonts || hasItemSizes
+	if ownerDrawn == lb.ownerDrawn {
+		return nil
+	}
+
+	style := uint32(GetWindowLong(lb.hWnd, GWL_STYLE))
+	if ownerDrawn {
+		style |= LBS_OWNERDRAWVARIABLE
+	} else {
+		style &^= LBS_OWNERDRAWVARIABLE
+	}
+
+	// WM_SETFONT of the new window already needs to know.
+	lb.ownerDrawn = ownerDrawn
+
+	if err := lb.recreateWindow("LISTBOX", style); err != nil {
+		lb.ownerDrawn = !ownerDrawn
+		return err
+	}
+
+	return nil
+}
+
 func (lb *ListBox) Format() string {
 	return lb.format
 }
@@ -246,6 +339,70 @@ func (lb *ListBox) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintpt
 		case LBN_DBLCLK:
 			lb.dblClickedPublisher.Publish()
 		}
+
+	case WM_VSCROLL, WM_MOUSEWHEEL, WM_KEYDOWN, WM_SIZE:
+		result := lb.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+
+		lb.updateVisibleRange()
+
+		if msg == WM_SIZE && lb.model != nil && lb.model.ItemCount() == 0 {
+			if _, ok := lb.model.(EmptyTextProvider); ok {
+				// The centered text moves, so the whole area needs a repaint.
+				lb.Invalidate()
+			}
+		}
+
+		return result
+
+	case WM_DRAWITEM:
+		dis := (*DRAWITEMSTRUCT)(unsafe.Pointer(lParam))
+
+		var text string
+		var font *Font
+		if lb.model != nil && int(dis.ItemID) < lb.model.ItemCount() && dis.ItemID >= 0 {
+			text = lb.itemString(int(dis.ItemID))
+
+			if ifp, ok := lb.model.(ItemFontProvider); ok {
+				font = ifp.ItemFont(int(dis.ItemID))
+			}
+		}
+
+		lb.drawListItem(dis, text, font)
+
+		return 1
+
+	case WM_MEASUREITEM:
+		mis := (*MEASUREITEMSTRUCT)(unsafe.Pointer(lParam))
+
+		if height := lb.itemHeight(int(mis.ItemID)); height > 0 {
+			mis.ItemHeight = uint32(height)
+		}
+
+		return 1
+
+	case WM_SETFONT:
+		result := lb.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+
+		// Owner drawn items do not adapt their height to the font by themselves.
+		if lb.ownerDrawn {
+			count := int(lb.SendMessage(LB_GETCOUNT, 0, 0))
+			for i := 0; i < count; i++ {
+				if height := lb.itemHeight(i); height > 0 {
+					lb.SendMessage(LB_SETITEMHEIGHT, uintptr(i), uintptr(height))
+				}
+			}
+		}
+
+		return result
+
+	case WM_PAINT:
+		result := lb.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+
+		if etp, ok := lb.model.(EmptyTextProvider); ok && lb.model.ItemCount() == 0 {
+			lb.drawEmptyText(etp.EmptyText(), lb.ClientBounds())
+		}
+
+		return result
 	}
 
 	return lb.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
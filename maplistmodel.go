@@ -0,0 +1,134 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// MapListModel is a ListModel over the entries of a map. Value returns the
+// value of an entry and BindingValue returns its key.
+//
+// The entries keep their order across calls to Refresh. Keys that were added to
+// the map since the last snapshot go to the end, unless the keys are sorted.
+type MapListModel struct {
+	ListModelBase
+	m        reflect.Value
+	sortKeys bool
+	keys     []reflect.Value
+}
+
+// NewMapListModel returns a new *MapListModel that presents the entries of m,
+// which must be a map. If sortKeys is true, the entries are sorted by key.
+func NewMapListModel(m interface{}, sortKeys bool) (*MapListModel, error) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return nil, newError("m must be a map")
+	}
+
+	model := &MapListModel{m: v, sortKeys: sortKeys}
+
+	model.snapshot()
+
+	return model, nil
+}
+
+// Refresh takes a new snapshot of the keys of the map and publishes the
+// ItemsReset event.
+func (m *MapListModel) Refresh() {
+	m.snapshot()
+
+	m.PublishItemsReset()
+}
+
+func (m *MapListModel) ItemCount() int {
+	return len(m.keys)
+}
+
+func (m *MapListModel) Value(index int) interface{} {
+	value := m.m.MapIndex(m.keys[index])
+	if !value.IsValid() {
+		// The entry was deleted since the last snapshot.
+		return nil
+	}
+
+	return value.Interface()
+}
+
+func (m *MapListModel) BindingValue(index int) interface{} {
+	return m.keys[index].Interface()
+}
+
+func (m *MapListModel) snapshot() {
+	mapKeys := m.m.MapKeys()
+
+	var keys []reflect.Value
+
+	if m.sortKeys {
+		keys = mapKeys
+
+		sort.Sort(keySorter(keys))
+	} else {
+		current := make(map[interface{}]bool, len(mapKeys))
+		for _, key := range mapKeys {
+			current[key.Interface()] = true
+		}
+
+		// Keep the known keys in their order and append the new ones.
+		known := make(map[interface{}]bool, len(m.keys))
+		for _, key := range m.keys {
+			if current[key.Interface()] {
+				keys = append(keys, key)
+				known[key.Interface()] = true
+			}
+		}
+
+		var added []reflect.Value
+		for _, key := range mapKeys {
+			if !known[key.Interface()] {
+				added = append(added, key)
+			}
+		}
+
+		// Map iteration order is random, so sort the new keys to be stable.
+		sort.Sort(keySorter(added))
+
+		keys = append(keys, added...)
+	}
+
+	m.keys = keys
+}
+
+type keySorter []reflect.Value
+
+func (ks keySorter) Len() int {
+	return len(ks)
+}
+
+func (ks keySorter) Less(i, j int) bool {
+	a, b := ks[i], ks[j]
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+
+	case reflect.String:
+		return a.String() < b.String()
+	}
+
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+func (ks keySorter) Swap(i, j int) {
+	ks[i], ks[j] = ks[j], ks[i]
+}
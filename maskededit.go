@@ -0,0 +1,255 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import . "github.com/lxn/go-winapi"
+
+// MaskedEdit is a LineEdit for numeric text with a fixed format, e.g. a phone
+// or part number.
+//
+// The mask consists of '#' for each digit and literal separators, e.g.
+// "###-###". Only digits can be typed, the separators are inserted
+// automatically and the caret skips them. Value returns the digits only.
+type MaskedEdit struct {
+	LineEdit
+	mask       []rune
+	formatting bool
+}
+
+// NewMaskedEdit returns a new *MaskedEdit without a mask, so it accepts any
+// text until SetMask is called.
+func NewMaskedEdit(parent Container) (*MaskedEdit, error) {
+	me := &MaskedEdit{}
+
+	if err := InitChildWidget(
+		me,
+		parent,
+		"EDIT",
+		WS_TABSTOP|WS_VISIBLE|ES_AUTOHSCROLL,
+		WS_EX_CLIENTEDGE); err != nil {
+		return nil, err
+	}
+
+	return me, nil
+}
+
+// Mask returns the mask of the *MaskedEdit.
+func (me *MaskedEdit) Mask() string {
+	return string(me.mask)
+}
+
+// SetMask sets the mask of the *MaskedEdit and formats the digits of the
+// current text accordingly. An empty mask turns masking off.
+func (me *MaskedEdit) SetMask(mask string) error {
+	me.mask = []rune(mask)
+
+	if mask == "" {
+		me.SetValidator(nil)
+		me.SetMaxLength(0)
+		return nil
+	}
+
+	me.SetValidator(NewMaskValidator(mask))
+	me.SetMaxLength(len(me.mask))
+
+	return me.setDigits(maskDigits(me.Text(), me.digitCapacity()), 0)
+}
+
+// Value returns the digits of the text, without the separators.
+func (me *MaskedEdit) Value() string {
+	if len(me.mask) == 0 {
+		return me.Text()
+	}
+
+	return string(maskDigits(me.Text(), me.digitCapacity()))
+}
+
+// SetValue sets the digits of the text. Other characters in value are ignored.
+func (me *MaskedEdit) SetValue(value string) error {
+	if len(me.mask) == 0 {
+		return me.SetText(value)
+	}
+
+	digits := maskDigits(value, me.digitCapacity())
+
+	return me.setDigits(digits, len(digits))
+}
+
+// digitCapacity returns the number of digits the mask has room for.
+func (me *MaskedEdit) digitCapacity() int {
+	var count int
+	for _, r := range me.mask {
+		if r == '#' {
+			count++
+		}
+	}
+
+	return count
+}
+
+// render returns the text that displays digits according to the mask. The
+// separators are only included up to the last digit.
+func (me *MaskedEdit) render(digits []rune) []rune {
+	var text []rune
+
+	i := 0
+	for _, r := range me.mask {
+		if i == len(digits) {
+			break
+		}
+
+		if r == '#' {
+			text = append(text, digits[i])
+			i++
+		} else {
+			text = append(text, r)
+		}
+	}
+
+	return text
+}
+
+// caretPos returns the text position of the digit with index digitIndex, or
+// the end of the text if there is no such digit.
+func (me *MaskedEdit) caretPos(digits []rune, digitIndex int) int {
+	text := me.render(digits)
+
+	i := 0
+	for pos, r := range me.mask {
+		if pos == len(text) {
+			break
+		}
+
+		if r == '#' {
+			if i == digitIndex {
+				return pos
+			}
+			i++
+		}
+	}
+
+	return len(text)
+}
+
+// digitIndex returns the number of digits in front of text position pos.
+func (me *MaskedEdit) digitIndex(pos int) int {
+	var count int
+	for i, r := range me.mask {
+		if i == pos {
+			break
+		}
+
+		if r == '#' {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (me *MaskedEdit) setDigits(digits []rune, caretDigitIndex int) error {
+	me.formatting = true
+	defer func() {
+		me.formatting = false
+	}()
+
+	if err := me.SetText(string(me.render(digits))); err != nil {
+		return err
+	}
+
+	pos := me.caretPos(digits, caretDigitIndex)
+	me.SetTextSelection(pos, pos)
+
+	return nil
+}
+
+// edit replaces the digits in the selection, or the digit before or after the
+// caret, if deleteBefore or deleteAfter is set, with insert.
+func (me *MaskedEdit) edit(insert []rune, deleteBefore, deleteAfter bool) {
+	digits := maskDigits(me.Text(), me.digitCapacity())
+
+	start, end := me.TextSelection()
+	first, last := me.digitIndex(start), me.digitIndex(end)
+
+	if first == last {
+		if deleteBefore && first > 0 {
+			first--
+		} else if deleteAfter && last < len(digits) {
+			last++
+		}
+	}
+
+	if first > len(digits) {
+		first = len(digits)
+	}
+	if last > len(digits) {
+		last = len(digits)
+	}
+
+	if len(digits)-(last-first)+len(insert) > me.digitCapacity() {
+		return
+	}
+
+	var result []rune
+	result = append(result, digits[:first]...)
+	result = append(result, insert...)
+	result = append(result, digits[last:]...)
+
+	me.setDigits(result, first+len(insert))
+}
+
+func (me *MaskedEdit) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	if len(me.mask) > 0 {
+		switch msg {
+		case WM_CHAR:
+			switch r := rune(wParam); {
+			case r >= '0' && r <= '9':
+				me.edit([]rune{r}, false, false)
+				return 0
+
+			case r == VK_BACK:
+				me.edit(nil, true, false)
+				return 0
+
+			case r >= ' ':
+				// Separators are inserted automatically.
+				return 0
+			}
+
+		case WM_KEYDOWN:
+			if wParam == VK_DELETE {
+				me.edit(nil, false, true)
+				return 0
+			}
+
+		case WM_COMMAND:
+			if HIWORD(uint32(wParam)) == EN_CHANGE && !me.formatting {
+				// Pasted or cut text is brought back into shape.
+				digits := maskDigits(me.Text(), me.digitCapacity())
+				if string(me.render(digits)) != me.Text() {
+					me.setDigits(digits, len(digits))
+				}
+			}
+		}
+	}
+
+	return me.LineEdit.WndProc(hwnd, msg, wParam, lParam)
+}
+
+// maskDigits returns up to max digits of s.
+func maskDigits(s string, max int) []rune {
+	var digits []rune
+	for _, r := range s {
+		if len(digits) == max {
+			break
+		}
+
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+
+	return digits
+}
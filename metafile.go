@@ -12,9 +12,10 @@ import (
 import . "github.com/lxn/go-winapi"
 
 type Metafile struct {
-	hdc  HDC
-	hemf HENHMETAFILE
-	size Size
+	hdc       HDC
+	hemf      HENHMETAFILE
+	size      Size
+	antialias bool
 }
 
 func NewMetafile(referenceCanvas *Canvas) (*Metafile, error) {
@@ -63,6 +64,41 @@ func (mf *Metafile) Save(filePath string) error {
 	return nil
 }
 
+// CopyToClipboard puts a copy of the *Metafile on the clipboard as
+// CF_ENHMETAFILE, e.g. for pasting it into a word processor.
+//
+// The system provides the CF_METAFILEPICT and CF_DIB formats to applications
+// that request them.
+func (mf *Metafile) CopyToClipboard() error {
+	if err := mf.ensureFinished(); err != nil {
+		return err
+	}
+
+	// The clipboard owns the handle after SetClipboardData succeeded.
+	hemf := CopyEnhMetaFile(mf.hemf, nil)
+	if hemf == 0 {
+		return newError("CopyEnhMetaFile failed")
+	}
+
+	if !OpenClipboard(0) {
+		DeleteEnhMetaFile(hemf)
+		return lastError("OpenClipboard")
+	}
+	defer CloseClipboard()
+
+	if !EmptyClipboard() {
+		DeleteEnhMetaFile(hemf)
+		return lastError("EmptyClipboard")
+	}
+
+	if SetClipboardData(CF_ENHMETAFILE, HANDLE(hemf)) == 0 {
+		DeleteEnhMetaFile(hemf)
+		return lastError("SetClipboardData")
+	}
+
+	return nil
+}
+
 func (mf *Metafile) readSizeFromHeader() error {
 	var hdr ENHMETAHEADER
 
@@ -101,6 +137,19 @@ func (mf *Metafile) Size() Size {
 	return mf.size
 }
 
+func (mf *Metafile) Antialias() bool {
+	return mf.antialias
+}
+
+// SetAntialias sets if the *Metafile is played back using the advanced graphics
+// mode and halftone stretching.
+//
+// This mostly benefits text and embedded bitmaps, e.g. when drawing into a
+// *Bitmap. GDI does not smooth lines and curves, so those are not affected.
+func (mf *Metafile) SetAntialias(value bool) {
+	mf.antialias = value
+}
+
 func (mf *Metafile) draw(hdc HDC, location Point) error {
 	return mf.drawStretched(hdc, Rectangle{location.X, location.Y, mf.size.Width, mf.size.Height})
 }
@@ -108,9 +157,309 @@ func (mf *Metafile) draw(hdc HDC, location Point) error {
 func (mf *Metafile) drawStretched(hdc HDC, bounds Rectangle) error {
 	rc := bounds.toRECT()
 
+	if mf.antialias {
+		oldMode, _, _ := setGraphicsMode.Call(uintptr(hdc), gmAdvanced)
+		if oldMode != 0 {
+			defer setGraphicsMode.Call(uintptr(hdc), oldMode)
+		}
+
+		oldStretchMode := SetStretchBltMode(hdc, HALFTONE)
+		defer SetStretchBltMode(hdc, oldStretchMode)
+
+		// Required after setting HALFTONE, see SetStretchBltMode docs.
+		SetBrushOrgEx(hdc, 0, 0, nil)
+	}
+
 	if !PlayEnhMetaFile(hdc, mf.hemf, &rc) {
 		return newError("PlayEnhMetaFile failed")
 	}
 
 	return nil
 }
+
+// DrawClipped draws the *Metafile stretched to bounds onto canvas, but only the
+// parts inside clip, e.g. for rendering a large metafile in tiles.
+//
+// The clipping region of canvas is restored afterwards.
+func (mf *Metafile) DrawClipped(canvas *Canvas, bounds, clip Rectangle) error {
+	if canvas == nil {
+		return newError("canvas cannot be nil")
+	}
+
+	if err := mf.ensureFinished(); err != nil {
+		return err
+	}
+
+	hdc := canvas.hdc
+
+	savedDC := SaveDC(hdc)
+	if savedDC == 0 {
+		return newError("SaveDC failed")
+	}
+	defer RestoreDC(hdc, savedDC)
+
+	if IntersectClipRect(
+		hdc,
+		int32(clip.X),
+		int32(clip.Y),
+		int32(clip.X+clip.Width),
+		int32(clip.Y+clip.Height)) == 0 {
+
+		return newError("IntersectClipRect failed")
+	}
+
+	return mf.drawStretched(hdc, bounds)
+}
+
+// MergeMetafiles records a new *Metafile that contains the parts, each played
+// back stretched to the rectangle layout returns for its index.
+//
+// The parts are left untouched and must still be disposed by the caller.
+func MergeMetafiles(refCanvas *Canvas, parts []*Metafile, layout func(i int) Rectangle) (*Metafile, error) {
+	if refCanvas == nil {
+		return nil, newError("refCanvas cannot be nil")
+	}
+	if layout == nil {
+		return nil, newError("layout cannot be nil")
+	}
+
+	merged, err := NewMetafile(refCanvas)
+	if err != nil {
+		return nil, err
+	}
+
+	succeeded := false
+	defer func() {
+		if !succeeded {
+			merged.Dispose()
+		}
+	}()
+
+	for i, part := range parts {
+		if part == nil {
+			return nil, newError("parts cannot contain nil")
+		}
+
+		if err := part.ensureFinished(); err != nil {
+			return nil, err
+		}
+
+		if err := part.drawStretched(merged.hdc, layout(i)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := merged.ensureFinished(); err != nil {
+		return nil, err
+	}
+
+	succeeded = true
+
+	return merged, nil
+}
+
+// Print prints the *Metafile on a single page, stretched to bounds in device
+// units of the printer. An empty printerName selects the default printer.
+func (mf *Metafile) Print(printerName string, bounds Rectangle) error {
+	if err := mf.ensureFinished(); err != nil {
+		return err
+	}
+
+	if printerName == "" {
+		var size uint32
+		getDefaultPrinter.Call(0, uintptr(unsafe.Pointer(&size)))
+		if size == 0 {
+			return lastError("GetDefaultPrinter")
+		}
+
+		buf := make([]uint16, size)
+		if ret, _, _ := getDefaultPrinter.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size))); ret == 0 {
+			return lastError("GetDefaultPrinter")
+		}
+
+		printerName = syscall.UTF16ToString(buf)
+	}
+
+	ret, _, _ := createDC.Call(0, uintptr(unsafe.Pointer(syscall.StringToUTF16Ptr(printerName))), 0, 0)
+	hdc := HDC(ret)
+	if hdc == 0 {
+		return newError("CreateDC failed")
+	}
+	defer DeleteDC(hdc)
+
+	di := docInfo{LpszDocName: syscall.StringToUTF16Ptr("Metafile")}
+	di.CbSize = int32(unsafe.Sizeof(di))
+
+	if ret, _, _ := startDoc.Call(uintptr(hdc), uintptr(unsafe.Pointer(&di))); int32(ret) <= 0 {
+		return newError("StartDoc failed")
+	}
+
+	succeeded := false
+	defer func() {
+		if !succeeded {
+			abortDoc.Call(uintptr(hdc))
+		}
+	}()
+
+	if StartPage(hdc) <= 0 {
+		return newError("StartPage failed")
+	}
+
+	if err := mf.drawStretched(hdc, bounds); err != nil {
+		return err
+	}
+
+	if EndPage(hdc) <= 0 {
+		return newError("EndPage failed")
+	}
+
+	if EndDoc(hdc) <= 0 {
+		return newError("EndDoc failed")
+	}
+
+	succeeded = true
+
+	return nil
+}
+
+func (mf *Metafile) drawBlended(hdc HDC, bounds Rectangle, alpha byte) error {
+	switch alpha {
+	case 0:
+		return nil
+
+	case 255:
+		return mf.drawStretched(hdc, bounds)
+	}
+
+	hdcMem := CreateCompatibleDC(hdc)
+	if hdcMem == 0 {
+		return newError("CreateCompatibleDC failed")
+	}
+	defer DeleteDC(hdcMem)
+
+	hBmp := CreateCompatibleBitmap(hdc, int32(bounds.Width), int32(bounds.Height))
+	if hBmp == 0 {
+		return newError("CreateCompatibleBitmap failed")
+	}
+	defer DeleteObject(HGDIOBJ(hBmp))
+
+	hBmpOld := SelectObject(hdcMem, HGDIOBJ(hBmp))
+	if hBmpOld == 0 {
+		return newError("SelectObject failed")
+	}
+	defer SelectObject(hdcMem, hBmpOld)
+
+	// Start with what is already there, so areas the metafile does not paint
+	// remain unchanged after blending.
+	if !BitBlt(
+		hdcMem,
+		0,
+		0,
+		int32(bounds.Width),
+		int32(bounds.Height),
+		hdc,
+		int32(bounds.X),
+		int32(bounds.Y),
+		SRCCOPY) {
+
+		return newError("BitBlt failed")
+	}
+
+	if err := mf.drawStretched(hdcMem, Rectangle{0, 0, bounds.Width, bounds.Height}); err != nil {
+		return err
+	}
+
+	// The zero BlendOp is AC_SRC_OVER.
+	bf := BLENDFUNCTION{SourceConstantAlpha: alpha}
+
+	if !AlphaBlend(
+		hdc,
+		int32(bounds.X),
+		int32(bounds.Y),
+		int32(bounds.Width),
+		int32(bounds.Height),
+		hdcMem,
+		0,
+		0,
+		int32(bounds.Width),
+		int32(bounds.Height),
+		bf) {
+
+		return newError("AlphaBlend failed")
+	}
+
+	return nil
+}
+
+// MetafilesEqual returns if a and b have the same size and render to identical
+// pixels, e.g. for regression tests of drawing code.
+func MetafilesEqual(a, b *Metafile) (bool, error) {
+	return MetafilesEqualWithTolerance(a, b, 0)
+}
+
+// MetafilesEqualWithTolerance returns if a and b have the same size and render
+// to pixels whose color channels differ by at most tolerance, e.g. to ignore
+// slight differences in anti-aliased text.
+func MetafilesEqualWithTolerance(a, b *Metafile, tolerance byte) (bool, error) {
+	if a == nil || b == nil {
+		return false, newError("metafiles cannot be nil")
+	}
+
+	if err := a.ensureFinished(); err != nil {
+		return false, err
+	}
+	if err := b.ensureFinished(); err != nil {
+		return false, err
+	}
+
+	if a.size != b.size {
+		return false, nil
+	}
+	if a.size.Width <= 0 || a.size.Height <= 0 {
+		return true, nil
+	}
+
+	pixelsA, err := a.rasterize()
+	if err != nil {
+		return false, err
+	}
+
+	pixelsB, err := b.rasterize()
+	if err != nil {
+		return false, err
+	}
+
+	if len(pixelsA) != len(pixelsB) {
+		return false, nil
+	}
+
+	for i, channelA := range pixelsA {
+		channelB := pixelsB[i]
+
+		if channelA > channelB && channelA-channelB > tolerance ||
+			channelB > channelA && channelB-channelA > tolerance {
+
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// rasterize plays back the *Metafile into a *Bitmap of its size and returns
+// the pixel data.
+func (mf *Metafile) rasterize() ([]byte, error) {
+	bmp, err := NewBitmap(mf.size)
+	if err != nil {
+		return nil, err
+	}
+	defer bmp.Dispose()
+
+	if err := bmp.withSelectedIntoMemDC(func(hdcMem HDC) error {
+		return mf.drawStretched(hdcMem, Rectangle{0, 0, mf.size.Width, mf.size.Height})
+	}); err != nil {
+		return nil, err
+	}
+
+	return bmp.pixels()
+}
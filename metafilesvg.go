@@ -0,0 +1,408 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// The EMF record types WriteSVG understands.
+const (
+	emrHeader              = 1
+	emrPolygon             = 3
+	emrPolyline            = 4
+	emrEOF                 = 14
+	emrSetTextColor        = 24
+	emrMoveToEx            = 27
+	emrSelectObject        = 37
+	emrCreatePen           = 38
+	emrCreateBrushIndirect = 39
+	emrDeleteObject        = 40
+	emrEllipse             = 42
+	emrRectangle           = 43
+	emrRoundRect           = 44
+	emrLineTo              = 54
+	emrCreateFontIndirectW = 82
+	emrExtTextOutW         = 84
+	emrPolygon16           = 86
+	emrPolyline16          = 87
+	emrExtCreatePen        = 95
+)
+
+const (
+	emfPSNull = 5
+	emfBSNull = 1
+)
+
+// Stock objects are selected by their index with the high bit set.
+const (
+	emfStockObject = 0x80000000
+
+	emfWhiteBrush  = 0
+	emfLtGrayBrush = 1
+	emfGrayBrush   = 2
+	emfDkGrayBrush = 3
+	emfBlackBrush  = 4
+	emfNullBrush   = 5
+	emfWhitePen    = 6
+	emfBlackPen    = 7
+	emfNullPen     = 8
+)
+
+var svgEscaper = strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&quot;")
+
+const (
+	emfPen = iota
+	emfBrush
+	emfFont
+)
+
+// emfObject is a pen, brush or font created by the records of a metafile.
+type emfObject struct {
+	kind     int
+	color    Color
+	width    int
+	null     bool
+	fontSize int
+}
+
+// svgWriter translates EMF records into SVG elements.
+type svgWriter struct {
+	buf       bytes.Buffer
+	objects   map[uint32]emfObject
+	pen       emfObject
+	brush     emfObject
+	fontSize  int
+	textColor Color
+	pos       Point
+}
+
+// WriteSVG writes an approximation of the *Metafile as SVG to w, e.g. for
+// reports on the web.
+//
+// Only a subset of the EMF records is translated, which covers the primitives
+// of typical charts: lines (MoveToEx, LineTo, Polyline), polygons, rectangles,
+// rounded rectangles, ellipses and text (ExtTextOutW), drawn with solid pens and
+// brushes, text colors and font heights. Other records, e.g. bitmaps, curves,
+// clipping and coordinate transforms, are ignored. Text is placed at its
+// reference point, as with the default text alignment.
+func (mf *Metafile) WriteSVG(w io.Writer) error {
+	if err := mf.ensureFinished(); err != nil {
+		return err
+	}
+
+	size, _, _ := getEnhMetaFileBits.Call(uintptr(mf.hemf), 0, 0)
+	if size == 0 {
+		return newError("GetEnhMetaFileBits failed")
+	}
+
+	data := make([]byte, size)
+	if ret, _, _ := getEnhMetaFileBits.Call(uintptr(mf.hemf), size, uintptr(unsafe.Pointer(&data[0]))); ret == 0 {
+		return newError("GetEnhMetaFileBits failed")
+	}
+
+	sw := &svgWriter{
+		objects: make(map[uint32]emfObject),
+		pen:     emfObject{width: 1},
+		brush:   emfObject{color: RGB(255, 255, 255)},
+	}
+
+	for len(data) >= 8 {
+		recType := binary.LittleEndian.Uint32(data)
+		recSize := binary.LittleEndian.Uint32(data[4:])
+		if recSize < 8 || uint64(recSize) > uint64(len(data)) {
+			return newError("invalid metafile record")
+		}
+
+		if recType == emrEOF {
+			break
+		}
+
+		sw.writeRecord(recType, data[:recSize])
+
+		data = data[recSize:]
+	}
+
+	sw.buf.WriteString("</svg>\n")
+
+	_, err := sw.buf.WriteTo(w)
+	return err
+}
+
+func (sw *svgWriter) writeRecord(recType uint32, rec []byte) {
+	switch recType {
+	case emrHeader:
+		if len(rec) < 24 {
+			return
+		}
+
+		left, top := emfInt32(rec, 8), emfInt32(rec, 12)
+		width, height := emfInt32(rec, 16)-left+1, emfInt32(rec, 20)-top+1
+
+		fmt.Fprintf(&sw.buf,
+			`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%d %d %d %d">`+"\n",
+			width, height, left, top, width, height)
+
+	case emrSetTextColor:
+		if len(rec) >= 12 {
+			sw.textColor = Color(emfUint32(rec, 8))
+		}
+
+	case emrCreatePen:
+		if len(rec) >= 28 {
+			sw.objects[emfUint32(rec, 8)] = emfObject{
+				kind:  emfPen,
+				null:  emfUint32(rec, 12)&0xF == emfPSNull,
+				width: int(emfInt32(rec, 16)),
+				color: Color(emfUint32(rec, 24)),
+			}
+		}
+
+	case emrExtCreatePen:
+		if len(rec) >= 44 {
+			sw.objects[emfUint32(rec, 8)] = emfObject{
+				kind:  emfPen,
+				null:  emfUint32(rec, 28)&0xF == emfPSNull,
+				width: int(emfUint32(rec, 32)),
+				color: Color(emfUint32(rec, 40)),
+			}
+		}
+
+	case emrCreateBrushIndirect:
+		if len(rec) >= 20 {
+			sw.objects[emfUint32(rec, 8)] = emfObject{
+				kind:  emfBrush,
+				null:  emfUint32(rec, 12) == emfBSNull,
+				color: Color(emfUint32(rec, 16)),
+			}
+		}
+
+	case emrCreateFontIndirectW:
+		if len(rec) >= 16 {
+			height := int(emfInt32(rec, 12))
+			if height < 0 {
+				height = -height
+			}
+
+			sw.objects[emfUint32(rec, 8)] = emfObject{kind: emfFont, fontSize: height}
+		}
+
+	case emrSelectObject:
+		if len(rec) >= 12 {
+			sw.selectObject(emfUint32(rec, 8))
+		}
+
+	case emrDeleteObject:
+		if len(rec) >= 12 {
+			delete(sw.objects, emfUint32(rec, 8))
+		}
+
+	case emrMoveToEx:
+		if len(rec) >= 16 {
+			sw.pos = Point{int(emfInt32(rec, 8)), int(emfInt32(rec, 12))}
+		}
+
+	case emrLineTo:
+		if len(rec) >= 16 {
+			to := Point{int(emfInt32(rec, 8)), int(emfInt32(rec, 12))}
+
+			fmt.Fprintf(&sw.buf, `<line x1="%d" y1="%d" x2="%d" y2="%d" %s/>`+"\n",
+				sw.pos.X, sw.pos.Y, to.X, to.Y, sw.strokeAttrs())
+
+			sw.pos = to
+		}
+
+	case emrPolyline, emrPolygon:
+		sw.writePoly(recType == emrPolygon, emfPoints(rec, false))
+
+	case emrPolyline16, emrPolygon16:
+		sw.writePoly(recType == emrPolygon16, emfPoints(rec, true))
+
+	case emrRectangle, emrRoundRect:
+		if len(rec) < 24 {
+			return
+		}
+
+		left, top := emfInt32(rec, 8), emfInt32(rec, 12)
+		right, bottom := emfInt32(rec, 16), emfInt32(rec, 20)
+
+		var rx, ry int32
+		if recType == emrRoundRect && len(rec) >= 32 {
+			rx, ry = emfInt32(rec, 24)/2, emfInt32(rec, 28)/2
+		}
+
+		fmt.Fprintf(&sw.buf, `<rect x="%d" y="%d" width="%d" height="%d" rx="%d" ry="%d" %s %s/>`+"\n",
+			left, top, right-left, bottom-top, rx, ry, sw.fillAttrs(), sw.strokeAttrs())
+
+	case emrEllipse:
+		if len(rec) < 24 {
+			return
+		}
+
+		left, top := float64(emfInt32(rec, 8)), float64(emfInt32(rec, 12))
+		right, bottom := float64(emfInt32(rec, 16)), float64(emfInt32(rec, 20))
+
+		fmt.Fprintf(&sw.buf, `<ellipse cx="%g" cy="%g" rx="%g" ry="%g" %s %s/>`+"\n",
+			(left+right)/2, (top+bottom)/2, (right-left)/2, (bottom-top)/2,
+			sw.fillAttrs(), sw.strokeAttrs())
+
+	case emrExtTextOutW:
+		if len(rec) < 52 {
+			return
+		}
+
+		x, y := emfInt32(rec, 36), emfInt32(rec, 40)
+		nChars, offString := emfUint32(rec, 44), emfUint32(rec, 48)
+		if uint64(offString)+2*uint64(nChars) > uint64(len(rec)) {
+			return
+		}
+
+		chars := make([]uint16, nChars)
+		for i := range chars {
+			chars[i] = binary.LittleEndian.Uint16(rec[int(offString)+2*i:])
+		}
+
+		fontSize := ""
+		if sw.fontSize > 0 {
+			fontSize = fmt.Sprintf(` font-size="%d"`, sw.fontSize)
+		}
+
+		fmt.Fprintf(&sw.buf, `<text x="%d" y="%d" dominant-baseline="text-before-edge" fill="%s"%s>%s</text>`+"\n",
+			x, y, svgColor(sw.textColor), fontSize, svgEscaper.Replace(string(utf16.Decode(chars))))
+	}
+}
+
+func (sw *svgWriter) selectObject(handle uint32) {
+	if handle&emfStockObject != 0 {
+		switch handle &^ emfStockObject {
+		case emfWhiteBrush:
+			sw.brush = emfObject{color: RGB(255, 255, 255)}
+		case emfLtGrayBrush:
+			sw.brush = emfObject{color: RGB(192, 192, 192)}
+		case emfGrayBrush:
+			sw.brush = emfObject{color: RGB(128, 128, 128)}
+		case emfDkGrayBrush:
+			sw.brush = emfObject{color: RGB(64, 64, 64)}
+		case emfBlackBrush:
+			sw.brush = emfObject{}
+		case emfNullBrush:
+			sw.brush = emfObject{null: true}
+		case emfWhitePen:
+			sw.pen = emfObject{color: RGB(255, 255, 255), width: 1}
+		case emfBlackPen:
+			sw.pen = emfObject{width: 1}
+		case emfNullPen:
+			sw.pen = emfObject{null: true}
+		}
+		return
+	}
+
+	obj, ok := sw.objects[handle]
+	if !ok {
+		return
+	}
+
+	switch obj.kind {
+	case emfPen:
+		sw.pen = obj
+	case emfBrush:
+		sw.brush = obj
+	case emfFont:
+		sw.fontSize = obj.fontSize
+	}
+}
+
+func (sw *svgWriter) writePoly(closed bool, points []Point) {
+	if len(points) == 0 {
+		return
+	}
+
+	var coords []string
+	for _, p := range points {
+		coords = append(coords, fmt.Sprintf("%d,%d", p.X, p.Y))
+	}
+
+	if closed {
+		fmt.Fprintf(&sw.buf, `<polygon points="%s" %s %s/>`+"\n",
+			strings.Join(coords, " "), sw.fillAttrs(), sw.strokeAttrs())
+	} else {
+		fmt.Fprintf(&sw.buf, `<polyline points="%s" fill="none" %s/>`+"\n",
+			strings.Join(coords, " "), sw.strokeAttrs())
+	}
+}
+
+func (sw *svgWriter) strokeAttrs() string {
+	if sw.pen.null {
+		return `stroke="none"`
+	}
+
+	width := sw.pen.width
+	if width < 1 {
+		width = 1
+	}
+
+	return fmt.Sprintf(`stroke="%s" stroke-width="%d"`, svgColor(sw.pen.color), width)
+}
+
+func (sw *svgWriter) fillAttrs() string {
+	if sw.brush.null {
+		return `fill="none"`
+	}
+
+	return fmt.Sprintf(`fill="%s"`, svgColor(sw.brush.color))
+}
+
+func svgColor(c Color) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R(), c.G(), c.B())
+}
+
+func emfUint32(rec []byte, offset int) uint32 {
+	return binary.LittleEndian.Uint32(rec[offset:])
+}
+
+func emfInt32(rec []byte, offset int) int32 {
+	return int32(emfUint32(rec, offset))
+}
+
+// emfPoints returns the points of a Polyline or Polygon record, which are
+// stored as 16 bit coordinates in the 16 bit variants of the records.
+func emfPoints(rec []byte, short bool) []Point {
+	if len(rec) < 28 {
+		return nil
+	}
+
+	count := int(emfUint32(rec, 24))
+
+	pointSize := 8
+	if short {
+		pointSize = 4
+	}
+
+	if count < 0 || 28+count*pointSize > len(rec) {
+		return nil
+	}
+
+	points := make([]Point, count)
+	for i := range points {
+		offset := 28 + i*pointSize
+
+		if short {
+			points[i] = Point{
+				int(int16(binary.LittleEndian.Uint16(rec[offset:]))),
+				int(int16(binary.LittleEndian.Uint16(rec[offset+2:]))),
+			}
+		} else {
+			points[i] = Point{int(emfInt32(rec, offset)), int(emfInt32(rec, offset+4))}
+		}
+	}
+
+	return points
+}
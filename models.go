@@ -4,6 +4,12 @@
 
 package walk
 
+import (
+	"encoding/json"
+)
+
+import . "github.com/lxn/go-winapi"
+
 // BindingValueProvider is the interface that a model must implement to support
 // data binding with widgets like ComboBox.
 type BindingValueProvider interface {
@@ -28,11 +34,43 @@ type ListModel interface {
 	ItemChanged() *IntEvent
 }
 
+// ItemsChangedNotifier is the interface that a ListModel can implement to
+// report changes of a contiguous block of items at once, so widgets like ListBox
+// can update them in a single pass.
+//
+// A model that implements it must publish the ItemsChanged event for every
+// change, including those of single items, as such widgets do not attach to
+// ItemChanged then. Embed a RangeListModelBase to opt in, it takes care of
+// this.
+type ItemsChangedNotifier interface {
+	// ItemsChanged returns the event that the model publishes, passing the
+	// first and the last index, when a range of items was changed.
+	ItemsChanged() *IntRangeEvent
+}
+
+// ReorderableModel is the interface that a model must implement to support
+// reordering its items, e.g. by drag and drop in a widget.
+type ReorderableModel interface {
+	// MoveItem moves the item at index from to index to, shifting the items in
+	// between. The model must publish a reset event afterwards.
+	MoveItem(from, to int) error
+}
+
+// PrefixMatcher is the interface that a ListModel can implement to efficiently
+// find items for autocompletion in a widget like ComboBox.
+type PrefixMatcher interface {
+	// MatchPrefix returns the index of the first item at or after index start
+	// whose text starts with prefix, ignoring case, or -1 if there is none.
+	MatchPrefix(prefix string, start int) int
+}
+
 // ListModelBase implements the ItemsReset and ItemChanged methods of the
 // ListModel interface.
 type ListModelBase struct {
 	itemsResetPublisher  EventPublisher
 	itemChangedPublisher IntEventPublisher
+	coalesceItemsReset   bool
+	itemsResetPending    bool
 }
 
 func (lmb *ListModelBase) ItemsReset() *Event {
@@ -43,14 +81,276 @@ func (lmb *ListModelBase) ItemChanged() *IntEvent {
 	return lmb.itemChangedPublisher.Event()
 }
 
+// CoalesceItemsReset returns if PublishItemsReset defers publishing the
+// ItemsReset event until the current message has been processed.
+func (lmb *ListModelBase) CoalesceItemsReset() bool {
+	return lmb.coalesceItemsReset
+}
+
+// SetCoalesceItemsReset sets if PublishItemsReset defers publishing the
+// ItemsReset event until the current message has been processed.
+//
+// This way, multiple calls to PublishItemsReset in a row, e.g. from a loader
+// that adds items in a loop, result in a single ItemsReset event and attached
+// widgets don't flicker.
+func (lmb *ListModelBase) SetCoalesceItemsReset(value bool) {
+	lmb.coalesceItemsReset = value
+}
+
 func (lmb *ListModelBase) PublishItemsReset() {
-	lmb.itemsResetPublisher.Publish()
+	if !lmb.coalesceItemsReset {
+		lmb.itemsResetPublisher.Publish()
+		return
+	}
+
+	if lmb.itemsResetPending {
+		return
+	}
+
+	lmb.itemsResetPending = true
+
+	synchronize(func() {
+		lmb.itemsResetPending = false
+
+		lmb.itemsResetPublisher.Publish()
+	})
+
+	// Make sure the message loop wakes up to run the synchronized func.
+	PostMessage(0, syncMsgId, 0, 0)
 }
 
 func (lmb *ListModelBase) PublishItemChanged(index int) {
 	lmb.itemChangedPublisher.Publish(index)
 }
 
+// PublishItemsChanged publishes the ItemChanged event for each of the items
+// from index from to index to, e.g. after updating a block of items.
+//
+// Embed a RangeListModelBase instead to let widgets update the block in a single
+// pass.
+func (lmb *ListModelBase) PublishItemsChanged(from, to int) error {
+	if from < 0 || from > to {
+		return newError("invalid range")
+	}
+
+	for index := from; index <= to; index++ {
+		lmb.itemChangedPublisher.Publish(index)
+	}
+
+	return nil
+}
+
+// RangeListModelBase is a ListModelBase that also implements
+// ItemsChangedNotifier, so widgets like ListBox update a block of changed items
+// in a single pass. Embed it instead of ListModelBase to opt in, and publish
+// changes only through PublishItemChanged and PublishItemsChanged.
+type RangeListModelBase struct {
+	ListModelBase
+	itemsChangedPublisher IntRangeEventPublisher
+}
+
+func (rlmb *RangeListModelBase) ItemsChanged() *IntRangeEvent {
+	return rlmb.itemsChangedPublisher.Event()
+}
+
+func (rlmb *RangeListModelBase) PublishItemChanged(index int) {
+	rlmb.ListModelBase.PublishItemChanged(index)
+	rlmb.itemsChangedPublisher.Publish(index, index)
+}
+
+// PublishItemsChanged publishes the ItemsChanged event for the items from index
+// from to index to, and the ItemChanged event for each of them, for handlers
+// that are not aware of ranges.
+func (rlmb *RangeListModelBase) PublishItemsChanged(from, to int) error {
+	if err := rlmb.ListModelBase.PublishItemsChanged(from, to); err != nil {
+		return err
+	}
+
+	rlmb.itemsChangedPublisher.Publish(from, to)
+
+	return nil
+}
+
+// LazyListModel is the interface that a ListModel must implement to fetch its
+// values on demand, e.g. from a database with a huge number of rows.
+//
+// Value may return a placeholder for items that have not been fetched yet. The
+// model should publish the ItemChanged event for those items once their data
+// has arrived.
+type LazyListModel interface {
+	ListModel
+
+	// SetVisibleRange is called by widgets like ListBox to tell the model which
+	// items are currently visible, so it can fetch them in advance.
+	SetVisibleRange(start, count int)
+}
+
+// LazyListModelBase implements the SetVisibleRange method of the LazyListModel
+// interface and caches the values of a LazyListModel.
+//
+// Provide a function that starts fetching a range of items by calling
+// SetFetchFunc and pass each fetched value to SetValue, from the goroutine that
+// runs the message loop. Your Value method can simply return LazyValue(index).
+type LazyListModelBase struct {
+	ListModelBase
+	values       map[int]interface{}
+	pending      map[int]bool
+	placeholder  interface{}
+	fetch        func(start, count int)
+	prefetch     int
+	visibleStart int
+	visibleCount int
+}
+
+// Placeholder returns the value that LazyValue returns for items that have not
+// been fetched yet.
+func (lmb *LazyListModelBase) Placeholder() interface{} {
+	return lmb.placeholder
+}
+
+// SetPlaceholder sets the value that LazyValue returns for items that have not
+// been fetched yet.
+func (lmb *LazyListModelBase) SetPlaceholder(value interface{}) {
+	lmb.placeholder = value
+}
+
+// Prefetch returns the number of items that are fetched in advance, before and
+// after the visible range.
+func (lmb *LazyListModelBase) Prefetch() int {
+	return lmb.prefetch
+}
+
+// SetPrefetch sets the number of items that are fetched in advance, before and
+// after the visible range.
+func (lmb *LazyListModelBase) SetPrefetch(count int) {
+	lmb.prefetch = count
+}
+
+// SetFetchFunc sets the function that is called to start fetching a range of
+// items.
+//
+// The range may extend past the last item of the model. The function should not
+// block, but deliver the values through SetValue later.
+func (lmb *LazyListModelBase) SetFetchFunc(fetch func(start, count int)) {
+	lmb.fetch = fetch
+}
+
+// VisibleRange returns the range of items that was last reported as visible.
+func (lmb *LazyListModelBase) VisibleRange() (start, count int) {
+	return lmb.visibleStart, lmb.visibleCount
+}
+
+// SetVisibleRange records the range of visible items and requests fetching
+// those of them, including the prefetch window, that are not cached yet.
+func (lmb *LazyListModelBase) SetVisibleRange(start, count int) {
+	lmb.visibleStart, lmb.visibleCount = start, count
+
+	lmb.requestFetch(start-lmb.prefetch, count+2*lmb.prefetch)
+}
+
+// LazyValue returns the cached value for the item at index index.
+//
+// If the value is not cached yet, the placeholder is returned and fetching the
+// item is requested, if it is inside the prefetch window.
+func (lmb *LazyListModelBase) LazyValue(index int) interface{} {
+	if value, ok := lmb.values[index]; ok {
+		return value
+	}
+
+	if lmb.visibleCount == 0 {
+		lmb.requestFetch(index, maxi(lmb.prefetch, 1))
+	} else if index >= lmb.visibleStart-lmb.prefetch &&
+		index < lmb.visibleStart+lmb.visibleCount+lmb.prefetch {
+
+		lmb.requestFetch(lmb.visibleStart-lmb.prefetch, lmb.visibleCount+2*lmb.prefetch)
+	}
+
+	return lmb.placeholder
+}
+
+// SetValue stores the fetched value for the item at index index and publishes
+// the ItemChanged event.
+func (lmb *LazyListModelBase) SetValue(index int, value interface{}) {
+	if lmb.values == nil {
+		lmb.values = make(map[int]interface{})
+	}
+
+	lmb.values[index] = value
+	delete(lmb.pending, index)
+
+	lmb.PublishItemChanged(index)
+}
+
+// ResetValues discards all cached values and publishes the ItemsReset event.
+func (lmb *LazyListModelBase) ResetValues() {
+	lmb.values = nil
+	lmb.pending = nil
+
+	lmb.PublishItemsReset()
+}
+
+func (lmb *LazyListModelBase) requestFetch(start, count int) {
+	if lmb.fetch == nil {
+		return
+	}
+
+	if start < 0 {
+		count += start
+		start = 0
+	}
+
+	if lmb.pending == nil {
+		lmb.pending = make(map[int]bool)
+	}
+
+	runStart := -1
+	for i := start; i <= start+count; i++ {
+		_, cached := lmb.values[i]
+		missing := i < start+count && !cached && !lmb.pending[i]
+
+		if missing {
+			lmb.pending[i] = true
+
+			if runStart == -1 {
+				runStart = i
+			}
+		} else if runStart != -1 {
+			lmb.fetch(runStart, i-runStart)
+
+			runStart = -1
+		}
+	}
+}
+
+// DataType specifies the type of the values of a TableColumn, to choose a
+// suitable default format and alignment.
+type DataType int
+
+const (
+	// DataTypeDefault formats values using %v.
+	DataTypeDefault DataType = iota
+
+	// DataTypeString displays values as text.
+	DataTypeString
+
+	// DataTypeInt formats integer values using %d, aligned to the right.
+	DataTypeInt
+
+	// DataTypeFloat formats values with Precision decimals, aligned to the
+	// right.
+	DataTypeFloat
+
+	// DataTypeBool formats values using %v, centered.
+	DataTypeBool
+
+	// DataTypeDate formats time.Time values as 2006-01-02.
+	DataTypeDate
+
+	// DataTypeCurrency formats values as currency of the user's locale, aligned
+	// to the right.
+	DataTypeCurrency
+)
+
 // TableColumn provides column information for widgets like TableView.
 type TableColumn struct {
 	// Name is the optional name of the column.
@@ -62,6 +362,10 @@ type TableColumn struct {
 	// Format is the format string for converting a value into a string.
 	Format string
 
+	// DataType is the type of the values of the column. It determines the
+	// format, if Format is empty, and the alignment, if Alignment is AlignNear.
+	DataType DataType
+
 	// Precision is the number of decimal places for formatting float32, float64
 	// or big.Rat values.
 	Precision int
@@ -71,6 +375,73 @@ type TableColumn struct {
 
 	// Alignment is the alignment of the column (who would have thought).
 	Alignment Alignment1D
+
+	// Hidden makes the column zero width, so it is not displayed, e.g. for a
+	// column that holds an id.
+	Hidden bool
+
+	// LessFunc is the optional function used by ColumnSorter to compare two
+	// values of the column.
+	LessFunc func(a, b interface{}) bool
+
+	// TitleFunc is the optional function that returns the text to display in
+	// the column header, e.g. "Name ▲" for a sorted column. It is called with
+	// the Title, if the model is sorted by the column and the sort order.
+	TitleFunc func(title string, sorted bool, order SortOrder) string
+}
+
+// columnLayout is the part of a TableColumn that MarshalColumns persists.
+type columnLayout struct {
+	Name   string
+	Width  int
+	Hidden bool `json:",omitempty"`
+}
+
+// MarshalColumns encodes the layout of cols, i.e. their order, Width and Hidden,
+// as JSON, e.g. to save the column customizations of the user.
+//
+// The columns are identified by Name, so each column must have a unique one.
+func MarshalColumns(cols []TableColumn) ([]byte, error) {
+	layouts := make([]columnLayout, len(cols))
+	names := make(map[string]bool, len(cols))
+
+	for i, col := range cols {
+		if col.Name == "" {
+			return nil, newError("column must have a name")
+		}
+		if names[col.Name] {
+			return nil, newError("duplicate column name: " + col.Name)
+		}
+		names[col.Name] = true
+
+		layouts[i] = columnLayout{col.Name, col.Width, col.Hidden}
+	}
+
+	data, err := json.Marshal(layouts)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalColumns decodes columns previously encoded by MarshalColumns.
+//
+// Only Name, Width and Hidden of the returned columns are set. Match them by
+// Name to the columns of your model to restore the layout.
+func UnmarshalColumns(data []byte) ([]TableColumn, error) {
+	var layouts []columnLayout
+
+	if err := json.Unmarshal(data, &layouts); err != nil {
+		return nil, wrapError(err)
+	}
+
+	cols := make([]TableColumn, len(layouts))
+	for i, layout := range layouts {
+		cols[i] = TableColumn{Name: layout.Name, Width: layout.Width, Hidden: layout.Hidden}
+	}
+
+	return cols, nil
 }
 
 // TableModel is the interface that a model must implement to support widgets
@@ -92,13 +463,18 @@ type TableModel interface {
 	// RowChanged returns the event that the model should publish when a row was
 	// changed.
 	RowChanged() *IntEvent
+
+	// ColumnsReset returns the event that the model should publish when the
+	// columns returned from Columns() changed.
+	ColumnsReset() *Event
 }
 
-// TableModelBase implements the RowsReset and RowChanged methods of the
-// TableModel interface.
+// TableModelBase implements the RowsReset, RowChanged and ColumnsReset methods
+// of the TableModel interface.
 type TableModelBase struct {
-	rowsResetPublisher  EventPublisher
-	rowChangedPublisher IntEventPublisher
+	rowsResetPublisher    EventPublisher
+	rowChangedPublisher   IntEventPublisher
+	columnsResetPublisher EventPublisher
 }
 
 func (tmb *TableModelBase) RowsReset() *Event {
@@ -117,6 +493,136 @@ func (tmb *TableModelBase) PublishRowChanged(row int) {
 	tmb.rowChangedPublisher.Publish(row)
 }
 
+func (tmb *TableModelBase) ColumnsReset() *Event {
+	return tmb.columnsResetPublisher.Event()
+}
+
+func (tmb *TableModelBase) PublishColumnsReset() {
+	tmb.columnsResetPublisher.Publish()
+}
+
+// CellSetter is the interface that a TableModel must implement to support
+// editing cell values.
+type CellSetter interface {
+	// SetValue sets the value of the given cell.
+	SetValue(row, col int, value interface{}) error
+}
+
+// DefaultCellValueProvider is the interface that a TableModel can implement to
+// provide initial cell values for newly added rows.
+type DefaultCellValueProvider interface {
+	// DefaultCellValue returns the initial value for cells of column col.
+	DefaultCellValue(col int) interface{}
+}
+
+// SetDefaultCellValues initializes the cells of row with the values returned
+// from the DefaultCellValue method of model.
+//
+// The model must implement CellSetter and DefaultCellValueProvider.
+func SetDefaultCellValues(model TableModel, row int) error {
+	setter, ok := model.(CellSetter)
+	if !ok {
+		return newError("model must implement CellSetter")
+	}
+
+	provider, ok := model.(DefaultCellValueProvider)
+	if !ok {
+		return newError("model must implement DefaultCellValueProvider")
+	}
+
+	for col := range model.Columns() {
+		if err := setter.SetValue(row, col, provider.DefaultCellValue(col)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CellEditabilityProvider is the interface that a CellSetter can implement to
+// mark individual cells as read-only.
+type CellEditabilityProvider interface {
+	// CellEditable returns if the cell at row and col may be edited.
+	CellEditable(row, col int) bool
+}
+
+// NextEditableCell returns the editable cell that follows the cell at row and
+// col in reading order, or precedes it if forward is false. The search wraps
+// from the end of a row to the next one, but not around the whole model.
+//
+// If model does not implement CellEditabilityProvider, all cells of a
+// CellSetter are considered editable and no cell of any other model is.
+func NextEditableCell(model TableModel, row, col int, forward bool) (int, int, bool) {
+	if _, ok := model.(CellSetter); !ok {
+		return 0, 0, false
+	}
+
+	colCount := len(model.Columns())
+	rowCount := model.RowCount()
+	if colCount == 0 || rowCount == 0 {
+		return 0, 0, false
+	}
+
+	provider, _ := model.(CellEditabilityProvider)
+
+	step := 1
+	if !forward {
+		step = -1
+	}
+
+	for i := row*colCount + col + step; i >= 0 && i < rowCount*colCount; i += step {
+		r, c := i/colCount, i%colCount
+
+		if provider == nil || provider.CellEditable(r, c) {
+			return r, c, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// RowValidator is the interface that a CellSetter can implement to validate a
+// row as a whole after one of its cells was edited, e.g. to check that an end
+// date is not before a start date.
+type RowValidator interface {
+	// ValidateRow returns an error if the values of row are not consistent.
+	ValidateRow(row int) error
+}
+
+// CommitCellValue sets the value of the cell at row and col, like an edit
+// would.
+//
+// If model implements RowValidator and the row fails validation, the previous
+// value of the cell is restored and the validation error is returned, so the
+// caller can show it to the user.
+func CommitCellValue(model TableModel, row, col int, value interface{}) error {
+	setter, ok := model.(CellSetter)
+	if !ok {
+		return newError("model must implement CellSetter")
+	}
+
+	oldValue := model.Value(row, col)
+
+	if err := setter.SetValue(row, col, value); err != nil {
+		return err
+	}
+
+	validator, ok := model.(RowValidator)
+	if !ok {
+		return nil
+	}
+
+	if err := validator.ValidateRow(row); err != nil {
+		if rollbackErr := setter.SetValue(row, col, oldValue); rollbackErr != nil {
+			return rollbackErr
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 // ImageProvider is the interface that a model must implement to support
 // displaying an item image. 
 type ImageProvider interface {
@@ -129,6 +635,142 @@ type ImageProvider interface {
 	Image(index int) interface{}
 }
 
+// ColumnImageProvider is the interface that a TableModel can implement to have
+// a widget like TableView display an image in the header of a column, e.g. a
+// lock on a protected column.
+type ColumnImageProvider interface {
+	// ColumnImage returns the image to display in the header of column col, or
+	// nil for a header with text only.
+	//
+	// Supported types are the same as for ImageProvider.
+	ColumnImage(col int) interface{}
+}
+
+// AccessibleValueProvider is the interface that a TableModel can implement to
+// supply the text screen readers announce for its cells, e.g. when a cell
+// displays an image or a value that reads badly.
+type AccessibleValueProvider interface {
+	// AccessibleValue returns the accessible text of the cell at row and col,
+	// or an empty string to leave the cell out.
+	AccessibleValue(row, col int) string
+}
+
+// CellSpanProvider is the interface that a TableModel can implement to have a
+// TableView merge cells, e.g. for a header cell spanning several columns.
+//
+// If spans overlap, the span whose cell comes first in reading order wins and
+// the spans of cells it covers are ignored. TableView looks for spans at most
+// 16 rows up, so longer row spans are cut off.
+type CellSpanProvider interface {
+	// CellSpan returns the number of rows and columns the cell at row and col
+	// spans. Both (0, 0) and (1, 1) mean no span.
+	CellSpan(row, col int) (rowSpan, colSpan int)
+}
+
+// ItemFontProvider is the interface that a ListModel can implement to have a
+// widget like ListBox or ComboBox display individual items in another font,
+// e.g. deprecated items in italics.
+type ItemFontProvider interface {
+	// ItemFont returns the font for the item at index, or nil for the font of
+	// the widget.
+	ItemFont(index int) *Font
+}
+
+// ItemSizeProvider is the interface that a ListModel can implement to have a
+// ListBox display items of different heights, e.g. multiline items or items
+// with large images.
+type ItemSizeProvider interface {
+	// ItemSize returns the size of the item at index. Only the height is used
+	// by ListBox.
+	ItemSize(index int) Size
+}
+
+// EditTextProvider is the interface that a ListModel can implement to have a
+// ComboBox display a different, e.g. more compact, text for the selected item
+// than in its drop-down list.
+type EditTextProvider interface {
+	// EditText returns the text to display for the item at index when it is
+	// selected.
+	EditText(index int) string
+}
+
+// EmptyTextProvider is the interface that a model must implement to have a
+// widget like TableView or ListBox display a text while it has no items.
+type EmptyTextProvider interface {
+	// EmptyText returns the text to display centered in the widget, e.g.
+	// "No results".
+	EmptyText() string
+}
+
+// LoadingStateProvider is the interface that a model can implement to have a
+// widget like TableView display a loading overlay instead of its items, while
+// the model fetches them in the background.
+type LoadingStateProvider interface {
+	// IsLoading returns if the model is currently loading its items.
+	IsLoading() bool
+
+	// LoadingChanged returns the event that the model publishes when it starts
+	// or finishes loading.
+	LoadingChanged() *Event
+}
+
+// LoadingStateBase implements the LoadingStateProvider interface. Embed it into
+// a model and call SetLoading before and after fetching the items.
+type LoadingStateBase struct {
+	loading                 bool
+	loadingChangedPublisher EventPublisher
+}
+
+func (lsb *LoadingStateBase) IsLoading() bool {
+	return lsb.loading
+}
+
+// SetLoading sets if the model is currently loading its items and publishes the
+// LoadingChanged event, if that changed.
+func (lsb *LoadingStateBase) SetLoading(loading bool) {
+	if loading == lsb.loading {
+		return
+	}
+
+	lsb.loading = loading
+
+	lsb.loadingChangedPublisher.Publish()
+}
+
+func (lsb *LoadingStateBase) LoadingChanged() *Event {
+	return lsb.loadingChangedPublisher.Event()
+}
+
+// NonReorderableColumnsProvider is the interface that a TableModel can
+// implement to keep its leading columns in place when the user reorders the
+// columns of a widget like TableView.
+//
+// The columns still scroll horizontally with the others, they are not pinned.
+// The list view control behind TableView can not keep columns stationary while
+// scrolling, so keeping them in front is the part of freezing columns that it
+// can honour.
+type NonReorderableColumnsProvider interface {
+	// NonReorderableColumnCount returns the number of leading columns that
+	// can not be reordered.
+	NonReorderableColumnCount() int
+}
+
+// RowHeightProvider is the interface that a model must implement to control
+// the row height in a widget like TableView.
+type RowHeightProvider interface {
+	// RowHeight returns the height in pixels of the row at index row, or 0 for
+	// the default height.
+	RowHeight(row int) int
+}
+
+// RowSelectableProvider is the interface that a TableModel can implement to
+// prevent some of its rows from being selected in a widget like TableView, e.g.
+// in a picker. Rows that are not selectable are still displayed.
+type RowSelectableProvider interface {
+	// RowSelectable returns if the row at index row can be selected.
+	RowSelectable(row int) bool
+}
+
 // ItemChecker is the interface that a model must implement to support check 
 // boxes in a widget like TableView.
 type ItemChecker interface {
@@ -139,6 +781,130 @@ type ItemChecker interface {
 	SetChecked(index int, checked bool) error
 }
 
+// RowKeyProvider is the interface that a model can implement to identify its
+// rows independently of their current index, e.g. to keep state attached to the
+// same logical items across sorting and filtering. See KeyedCheckerBase.
+type RowKeyProvider interface {
+	// RowKey returns a stable key of the row at index row. Keys must be
+	// comparable and unique among the rows of the model.
+	RowKey(row int) interface{}
+}
+
+// ExpandableModel is the interface that a TableModel can implement to have
+// rows that expand to show details, e.g. in a master-detail view.
+type ExpandableModel interface {
+	// IsExpandable returns if row has details to show.
+	IsExpandable(row int) bool
+
+	// IsExpanded returns if the details of row are shown.
+	IsExpanded(row int) bool
+
+	// SetExpanded sets if the details of row are shown. The model must publish
+	// the ExpandedChanged event, if that changed.
+	SetExpanded(row int, expanded bool)
+
+	// ExpandedChanged returns the event that the model publishes, passing the
+	// row, after a row was expanded or collapsed.
+	ExpandedChanged() *IntEvent
+}
+
+// ExpandableModelBase implements the IsExpanded, SetExpanded and
+// ExpandedChanged methods of the ExpandableModel interface.
+//
+// The expanded rows are tracked by index, so call CollapseAll when the rows of
+// the model are reset.
+type ExpandableModelBase struct {
+	expanded                 map[int]bool
+	expandedChangedPublisher IntEventPublisher
+}
+
+func (emb *ExpandableModelBase) IsExpanded(row int) bool {
+	return emb.expanded[row]
+}
+
+func (emb *ExpandableModelBase) SetExpanded(row int, expanded bool) {
+	if expanded == emb.expanded[row] {
+		return
+	}
+
+	if expanded {
+		if emb.expanded == nil {
+			emb.expanded = make(map[int]bool)
+		}
+
+		emb.expanded[row] = true
+	} else {
+		delete(emb.expanded, row)
+	}
+
+	emb.expandedChangedPublisher.Publish(row)
+}
+
+// CollapseAll collapses all rows, without publishing the ExpandedChanged event.
+func (emb *ExpandableModelBase) CollapseAll() {
+	emb.expanded = nil
+}
+
+func (emb *ExpandableModelBase) ExpandedChanged() *IntEvent {
+	return emb.expandedChangedPublisher.Event()
+}
+
+// BulkItemChecker is the interface that an ItemChecker can implement to
+// efficiently check or uncheck many items at once.
+//
+// Implementations should publish a single change event, e.g. ItemsReset or
+// RowsReset, instead of one per item.
+type BulkItemChecker interface {
+	// SetCheckedRange sets if count items, starting at index start, are
+	// checked.
+	SetCheckedRange(start, count int, checked bool) error
+
+	// SetAllChecked sets if all items are checked.
+	SetAllChecked(checked bool) error
+}
+
+// CheckState specifies the check state of an item.
+type CheckState int
+
+const (
+	// CheckUnchecked specifies that an item is not checked.
+	CheckUnchecked CheckState = iota
+
+	// CheckChecked specifies that an item is checked.
+	CheckChecked
+
+	// CheckIndeterminate specifies that an item is partially checked, e.g.
+	// because only some of its children are checked.
+	CheckIndeterminate
+)
+
+// TriStateItemChecker is the interface that a model can implement to keep check
+// states that include an indeterminate one, e.g. using a TriStateCheckerBase.
+//
+// It is a contract between models and their users only. No widget draws the
+// indeterminate state yet, a TableView shows check boxes through ItemChecker,
+// so an indeterminate item appears as its Checked method reports it, which is
+// unchecked for a TriStateCheckerBase.
+type TriStateItemChecker interface {
+	// CheckState returns the check state of the specified item.
+	CheckState(index int) CheckState
+
+	// SetCheckState sets the check state of the specified item.
+	SetCheckState(index int, state CheckState) error
+}
+
+// PersistableModel is the interface that a model can implement to persist its
+// own UI related state, e.g. sorting or column layout, together with the state
+// of a widget like TableView. See *App.Settings for details.
+type PersistableModel interface {
+	// SaveState returns the state of the model, serialized to a string.
+	SaveState() string
+
+	// RestoreState restores the state of the model from a string, previously
+	// returned by SaveState.
+	RestoreState(state string) error
+}
+
 // SortOrder specifies the order by which items are sorted.
 type SortOrder int
 
@@ -180,9 +946,19 @@ type Sorter interface {
 // SorterBase implementation so the SortChanged event, that e.g. a TableView
 // widget depends on, is published.
 type SorterBase struct {
-	changedPublisher EventPublisher
-	col              int
-	order            SortOrder
+	changedPublisher   EventPublisher
+	col                int
+	order              SortOrder
+	defaultCol         int
+	defaultOrder       SortOrder
+	defaultSortPending bool
+	tieBreaker         func(i, j int) bool
+}
+
+// defaultSorter is implemented by models that embed a SorterBase, so a widget
+// like TableView can apply the default sort.
+type defaultSorter interface {
+	takeDefaultSort() (col int, order SortOrder, ok bool)
 }
 
 func (sb *SorterBase) ColumnSortable(col int) bool {
@@ -208,3 +984,58 @@ func (sb *SorterBase) SortedColumn() int {
 func (sb *SorterBase) SortOrder() SortOrder {
 	return sb.order
 }
+
+// DefaultSort returns the column and order set by SetDefaultSort.
+func (sb *SorterBase) DefaultSort() (col int, order SortOrder) {
+	return sb.defaultCol, sb.defaultOrder
+}
+
+// SetDefaultSort sets the column and order that a widget like TableView sorts
+// the model by, the first time it displays rows of the model.
+//
+// Unlike restoring a sort state, this actually calls the Sort method of the
+// model.
+func (sb *SorterBase) SetDefaultSort(col int, order SortOrder) {
+	sb.defaultCol, sb.defaultOrder = col, order
+	sb.defaultSortPending = true
+}
+
+// TieBreaker returns the func set by SetTieBreaker.
+func (sb *SorterBase) TieBreaker() func(i, j int) bool {
+	return sb.tieBreaker
+}
+
+// SetTieBreaker sets the func that decides the order of rows i and j, if their
+// values of the sorted column are equal, e.g. by comparing IDs.
+//
+// The tie breaker applies regardless of the sort order. ColumnSorter uses it,
+// custom Sort implementations should too.
+func (sb *SorterBase) SetTieBreaker(tieBreaker func(i, j int) bool) {
+	sb.tieBreaker = tieBreaker
+}
+
+// AttachToReset attaches to reset, usually the RowsReset event of the model,
+// and clears the sort state each time reset is published, so a widget like
+// TableView does not display a stale sort indicator after the rows were
+// reloaded. It returns the handle to detach from reset.
+//
+// SorterBase cannot sort the rows itself. A model with its own Sort method, that
+// wants to keep its rows sorted instead, should attach to reset and call Sort
+// with SortedColumn and SortOrder. ColumnSorter does that in its AttachToReset.
+func (sb *SorterBase) AttachToReset(reset *Event) int {
+	return reset.Attach(func() {
+		if sb.col > -1 {
+			sb.Sort(-1, sb.order)
+		}
+	})
+}
+
+func (sb *SorterBase) takeDefaultSort() (col int, order SortOrder, ok bool) {
+	if !sb.defaultSortPending {
+		return 0, 0, false
+	}
+
+	sb.defaultSortPending = false
+
+	return sb.defaultCol, sb.defaultOrder, true
+}
@@ -5,14 +5,29 @@
 package walk
 
 import (
+	"errors"
 	"math"
+	"math/big"
+	"sort"
 	"strconv"
+	"strings"
 	"syscall"
 	"unsafe"
 )
 
 import . "github.com/lxn/go-winapi"
 
+// StepMode specifies how the spinner of a NumberEdit changes its value.
+type StepMode int
+
+const (
+	// StepLinear adds or subtracts the increment for each step.
+	StepLinear StepMode = iota
+
+	// StepLogarithmic multiplies or divides by the increment for each step.
+	StepLogarithmic
+)
+
 const numberEditWindowClass = `\o/ Walk_NumberEdit_Class \o/`
 
 func init() {
@@ -21,16 +36,57 @@ func init() {
 
 type NumberEdit struct {
 	WidgetBase
-	edit                  *LineEdit
-	hWndUpDown            HWND
-	bindingMember         string
-	increment             float64
-	oldValue              float64
-	valueChangedPublisher EventPublisher
+	edit                      *LineEdit
+	hWndUpDown                HWND
+	bindingMember             string
+	increment                 float64
+	incrementFunc             func(current float64, up bool) float64
+	stepMode                  StepMode
+	spinButtonHidden          bool
+	textAlignment             Alignment1D
+	spinnerTabStop            bool
+	defaultContextMenu        *Menu
+	hasSpinRange              bool
+	spinMin                   float64
+	spinMax                   float64
+	caretRelativeStepping     bool
+	silent                    bool
+	validationMessage         string
+	allowedValues             []float64
+	locale                    LCID
+	formatFunc                func(value float64) string
+	parseFunc                 func(s string) (float64, error)
+	fractionMode              bool
+	maxDenominator            int
+	baseline                  float64
+	baselineNull              bool
+	dirty                     bool
+	dirtyChangedPublisher     EventPublisher
+	trackStats                bool
+	hasStats                  bool
+	minEntered                float64
+	maxEntered                float64
+	oldValue                  float64
+	ratMode                   bool
+	nullable                  bool
+	isNull                    bool
+	nullText                  string
+	clampToRange              bool
+	valueChangedPublisher     EventPublisher
+	valueChangedExPublisher   Float64PairEventPublisher
+	valueChangedFPublisher    Float64EventPublisher
+	valueClampedPublisher     Float64PairEventPublisher
+	committedPublisher        EventPublisher
+	formattedTextPublisher    StringEventPublisher
+	incrementChangedPublisher EventPublisher
+	boundFloat64              *float64
+	boundValueChangedHandle   int
+	boundRefresh              *Event
+	boundRefreshHandle        int
 }
 
 func NewNumberEdit(parent Container) (*NumberEdit, error) {
-	ne := &NumberEdit{increment: 1}
+	ne := &NumberEdit{increment: 1, textAlignment: AlignFar, maxDenominator: 16}
 
 	if err := InitChildWidget(
 		ne,
@@ -56,14 +112,18 @@ func NewNumberEdit(parent Container) (*NumberEdit, error) {
 	if err = ne.edit.setAndClearStyleBits(ES_RIGHT, ES_LEFT|ES_CENTER); err != nil {
 		return nil, err
 	}
-	nv := NewNumberValidator()
+	nv, err := NewNumberValidatorWithRange(0, 100, 2)
+	if err != nil {
+		return nil, err
+	}
 	ne.edit.SetValidator(nv)
-	nv.SetDecimals(2)
-	nv.SetRange(0, 100)
 
+	// The up-down control is created after the edit and without WS_TABSTOP,
+	// so tabbing into the *NumberEdit focuses the edit and tabbing on leaves
+	// it, see SetSpinnerTabStop.
 	ne.hWndUpDown = CreateWindowEx(
 		0, syscall.StringToUTF16Ptr("msctls_updown32"), nil,
-		WS_CHILD|WS_VISIBLE|UDS_ALIGNRIGHT|UDS_ARROWKEYS|UDS_HOTTRACK,
+		WS_CHILD|WS_VISIBLE|UDS_ALIGNRIGHT|UDS_HOTTRACK,
 		0, 0, 16, 20, ne.hWnd, 0, 0, nil)
 	if ne.hWndUpDown == 0 {
 		return nil, lastError("CreateWindowEx")
@@ -71,6 +131,24 @@ func NewNumberEdit(parent Container) (*NumberEdit, error) {
 
 	SendMessage(ne.hWndUpDown, UDM_SETBUDDY, uintptr(ne.edit.hWnd), 0)
 
+	ne.updateSpinnerRange()
+
+	// We handle the arrow keys ourselves instead of using UDS_ARROWKEYS, so
+	// they keep working while the spin button is hidden.
+	ne.edit.KeyDown().Attach(func(key int) {
+		switch key {
+		case VK_UP:
+			ne.step(1)
+
+		case VK_DOWN:
+			ne.step(-1)
+		}
+	})
+
+	if err = ne.initDefaultContextMenu(); err != nil {
+		return nil, err
+	}
+
 	if err = ne.SetValue(0); err != nil {
 		return nil, err
 	}
@@ -80,6 +158,108 @@ func NewNumberEdit(parent Container) (*NumberEdit, error) {
 	return ne, nil
 }
 
+func (ne *NumberEdit) initDefaultContextMenu() error {
+	menu, err := NewMenu()
+	if err != nil {
+		return err
+	}
+	ne.defaultContextMenu = menu
+
+	addAction := func(text string, triggered func()) error {
+		action := NewAction()
+		if err := action.SetText(text); err != nil {
+			return err
+		}
+		if triggered != nil {
+			action.Triggered().Attach(triggered)
+		}
+
+		return menu.Actions().Add(action)
+	}
+
+	if err := addAction("&Copy", func() {
+		ne.edit.SendMessage(WM_COPY, 0, 0)
+	}); err != nil {
+		return err
+	}
+	if err := addAction("&Paste", func() {
+		ne.edit.SendMessage(WM_PASTE, 0, 0)
+	}); err != nil {
+		return err
+	}
+	if err := addAction("-", nil); err != nil {
+		return err
+	}
+	if err := addAction("&Increment", func() {
+		ne.step(1)
+	}); err != nil {
+		return err
+	}
+	if err := addAction("&Decrement", func() {
+		ne.step(-1)
+	}); err != nil {
+		return err
+	}
+
+	ne.SetContextMenu(menu)
+
+	return nil
+}
+
+func (ne *NumberEdit) Dispose() {
+	ne.unbindFloat64()
+
+	if ne.defaultContextMenu != nil {
+		ne.defaultContextMenu.Dispose()
+		ne.defaultContextMenu = nil
+	}
+
+	ne.WidgetBase.Dispose()
+}
+
+// SetContextMenu sets the context menu of the *NumberEdit, which is shown for
+// the edit and the spin button.
+//
+// By default, a *NumberEdit has a menu to copy, paste, increment and decrement.
+// Pass nil to get the standard menu of the edit control instead.
+func (ne *NumberEdit) SetContextMenu(value *Menu) {
+	ne.edit.SetContextMenu(value)
+	ne.WidgetBase.SetContextMenu(value)
+}
+
+// TextAlignment returns the alignment of the text in the *NumberEdit.
+func (ne *NumberEdit) TextAlignment() Alignment1D {
+	return ne.textAlignment
+}
+
+// SetTextAlignment sets the alignment of the text in the *NumberEdit. Numbers
+// are aligned to the right by default.
+func (ne *NumberEdit) SetTextAlignment(alignment Alignment1D) error {
+	var style uint32
+
+	switch alignment {
+	case AlignNear:
+		style = ES_LEFT
+
+	case AlignCenter:
+		style = ES_CENTER
+
+	case AlignFar:
+		style = ES_RIGHT
+
+	default:
+		return newError("invalid alignment")
+	}
+
+	if err := ne.edit.setAndClearStyleBits(style, (ES_LEFT|ES_CENTER|ES_RIGHT)&^style); err != nil {
+		return err
+	}
+
+	ne.textAlignment = alignment
+
+	return ne.edit.Invalidate()
+}
+
 func (ne *NumberEdit) Enabled() bool {
 	return ne.WidgetBase.Enabled()
 }
@@ -136,10 +316,18 @@ func (ne *NumberEdit) SetBindingMember(member string) error {
 }
 
 func (ne *NumberEdit) BindingValue() interface{} {
+	if ne.ratMode {
+		return ne.RatValue()
+	}
+
 	return ne.Value()
 }
 
 func (ne *NumberEdit) SetBindingValue(value interface{}) error {
+	if r, ok := value.(*big.Rat); ok {
+		return ne.SetRatValue(r)
+	}
+
 	return ne.SetValue(value.(float64))
 }
 
@@ -152,111 +340,1428 @@ func (ne *NumberEdit) Decimals() int {
 }
 
 func (ne *NumberEdit) SetDecimals(value int) error {
+	if ne.isNull {
+		return ne.edit.Validator().(*NumberValidator).SetDecimals(value)
+	}
+
+	var r *big.Rat
+	if ne.ratMode {
+		r = ne.RatValue()
+	}
+
 	if err := ne.edit.Validator().(*NumberValidator).SetDecimals(value); err != nil {
 		return err
 	}
 
-	return ne.SetValue(ne.oldValue)
+	if ne.ratMode {
+		return ne.SetRatValue(r)
+	}
+
+	return ne.setValue(ne.oldValue)
 }
 
-func (ne *NumberEdit) Increment() float64 {
-	return ne.increment
+// SetAccessibleName sets the name screen readers announce for the *NumberEdit.
+//
+// The name is also set on the inner edit control, because that is what
+// receives the focus.
+func (ne *NumberEdit) SetAccessibleName(name string) error {
+	if err := ne.edit.SetAccessibleName(name); err != nil {
+		return err
+	}
+
+	return ne.WidgetBase.SetAccessibleName(name)
 }
 
-func (ne *NumberEdit) SetIncrement(value float64) error {
-	ne.increment = value
+// FormatFunc returns the func that formats the value of the *NumberEdit for
+// display, or nil if Decimals and Locale determine the format.
+func (ne *NumberEdit) FormatFunc() func(value float64) string {
+	return ne.formatFunc
+}
 
-	return nil
+// SetFormatFunc sets the func that formats the value of the *NumberEdit for
+// display, e.g. to show a number of bytes as "1.5 GB". Pass nil to restore the
+// default format.
+//
+// A custom format usually needs a matching parse func, see SetParseFunc.
+func (ne *NumberEdit) SetFormatFunc(formatFunc func(value float64) string) error {
+	value := ne.Value()
+
+	ne.formatFunc = formatFunc
+
+	if ne.isNull {
+		return nil
+	}
+
+	return ne.setValue(value)
 }
 
-func (ne *NumberEdit) MinValue() float64 {
-	return ne.edit.Validator().(*NumberValidator).MinValue()
+// ParseFunc returns the func that parses the text of the *NumberEdit, or nil if
+// the default parsing applies.
+func (ne *NumberEdit) ParseFunc() func(s string) (float64, error) {
+	return ne.parseFunc
 }
 
-func (ne *NumberEdit) MaxValue() float64 {
-	return ne.edit.Validator().(*NumberValidator).MaxValue()
+// SetParseFunc sets the func that parses the text of the *NumberEdit into its
+// value. Pass nil to restore the default parsing.
+func (ne *NumberEdit) SetParseFunc(parseFunc func(s string) (float64, error)) {
+	ne.parseFunc = parseFunc
 }
 
-func (ne *NumberEdit) SetRange(min, max float64) error {
-	return ne.edit.Validator().(*NumberValidator).SetRange(min, max)
+// FractionMode returns if the *NumberEdit displays its value as a mixed
+// fraction, e.g. "1 1/2".
+func (ne *NumberEdit) FractionMode() bool {
+	return ne.fractionMode
 }
 
-func (ne *NumberEdit) Value() float64 {
-	val, _ := parseFloat(ne.edit.Text())
-	return val
+// SetFractionMode sets if the *NumberEdit displays its value as a mixed
+// fraction, e.g. "1 1/2" for measurements in inches.
+//
+// In fraction mode, the value is rounded to the nearest multiple of 1 divided
+// by MaxDenominator for display and the spin button steps by that amount. Text
+// like "1 1/2", "3/4" or "2" is accepted as input. A custom FormatFunc or
+// ParseFunc takes precedence over fraction mode.
+func (ne *NumberEdit) SetFractionMode(enabled bool) error {
+	if enabled == ne.fractionMode {
+		return nil
+	}
+
+	value := ne.Value()
+
+	ne.fractionMode = enabled
+
+	if ne.isNull {
+		return nil
+	}
+
+	return ne.setValue(value)
+}
+
+// MaxDenominator returns the largest denominator of the fractions displayed in
+// fraction mode. The default is 16.
+func (ne *NumberEdit) MaxDenominator() int {
+	return ne.maxDenominator
+}
+
+// SetMaxDenominator sets the largest denominator of the fractions displayed in
+// fraction mode, e.g. 16 to display sixteenths of an inch.
+func (ne *NumberEdit) SetMaxDenominator(denominator int) error {
+	if denominator < 1 {
+		return newError("denominator must be positive")
+	}
+
+	value := ne.Value()
+
+	ne.maxDenominator = denominator
+
+	if !ne.fractionMode || ne.isNull {
+		return nil
+	}
+
+	return ne.setValue(value)
 }
 
-func (ne *NumberEdit) SetValue(value float64) (err error) {
+// formatFraction formats value as a mixed fraction, rounded to the nearest
+// multiple of 1/maxDenominator, e.g. "-1 3/8".
+func formatFraction(value float64, maxDenominator int) string {
+	units := int64(math.Floor(math.Abs(value)*float64(maxDenominator) + 0.5))
+	whole := units / int64(maxDenominator)
+	frac := new(big.Rat).SetFrac64(units%int64(maxDenominator), int64(maxDenominator))
+
 	var text string
-	prec := ne.Decimals()
+	switch {
+	case frac.Sign() == 0:
+		text = strconv.FormatInt(whole, 10)
 
-	if prec == 0 {
-		text = strconv.Itoa(int(value))
-	} else {
-		text, err = formatFloat(value, prec)
+	case whole == 0:
+		text = frac.String()
+
+	default:
+		text = strconv.FormatInt(whole, 10) + " " + frac.String()
+	}
+
+	if value < 0 && units != 0 {
+		text = "-" + text
+	}
+
+	return text
+}
+
+// Not a walk error, so partial input doesn't get logged or panic.
+var errInvalidFraction = errors.New("invalid fraction")
+
+// parseFraction parses s as a mixed fraction like "1 1/2" or "3/4", or as a
+// plain number formatted for locale.
+func parseFraction(s string, locale LCID) (float64, error) {
+	s = strings.TrimSpace(s)
+
+	var neg bool
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = s[1:]
+	}
+
+	fields := strings.Fields(s)
+
+	var value float64
+	switch {
+	case len(fields) == 1 && !strings.Contains(fields[0], "/"):
+		v, err := parseFloatLocale(fields[0], locale)
 		if err != nil {
-			return
+			return 0, err
+		}
+		value = v
+
+	case len(fields) == 1:
+		v, err := parseProperFraction(fields[0])
+		if err != nil {
+			return 0, err
+		}
+		value = v
+
+	case len(fields) == 2:
+		whole, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return 0, errInvalidFraction
 		}
+
+		v, err := parseProperFraction(fields[1])
+		if err != nil {
+			return 0, err
+		}
+		value = float64(whole) + v
+
+	default:
+		return 0, errInvalidFraction
 	}
 
-	if err = ne.edit.SetText(text); err != nil {
-		return
+	if neg {
+		value = -value
 	}
 
-	return
+	return value, nil
 }
 
-func (ne *NumberEdit) ValueChanged() *Event {
-	return ne.valueChangedPublisher.Event()
+// parseProperFraction parses s as a fraction like "3/4".
+func parseProperFraction(s string) (float64, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return 0, errInvalidFraction
+	}
+
+	num, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, errInvalidFraction
+	}
+
+	den, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil || den == 0 {
+		return 0, errInvalidFraction
+	}
+
+	return float64(num) / float64(den), nil
 }
 
-func (ne *NumberEdit) SetFocus() error {
-	if SetFocus(ne.edit.hWnd) == 0 {
-		return lastError("SetFocus")
+// Locale returns the locale whose separators the *NumberEdit uses to format and
+// parse its text, or 0 if it uses the locale of the user.
+func (ne *NumberEdit) Locale() LCID {
+	return ne.locale
+}
+
+// SetLocale sets the locale whose separators the *NumberEdit uses to format and
+// parse its text. Pass 0 to use the locale of the user.
+//
+// locale is a Windows locale identifier, e.g. 0x0407 for German (Germany),
+// because the text is formatted by Windows, like everywhere else in walk.
+func (ne *NumberEdit) SetLocale(locale LCID) error {
+	if locale == ne.locale {
+		return nil
+	}
+
+	var r *big.Rat
+	if ne.ratMode {
+		r = ne.RatValue()
+	}
+	value := ne.Value()
+
+	ne.locale = locale
+
+	if ne.isNull {
+		return nil
+	}
+
+	if ne.ratMode {
+		return ne.SetRatValue(r)
+	}
+
+	return ne.setValue(value)
+}
+
+func (ne *NumberEdit) numberLocale() LCID {
+	if ne.locale == 0 {
+		return LOCALE_USER_DEFAULT
 	}
 
+	return ne.locale
+}
+
+func (ne *NumberEdit) Increment() float64 {
+	return ne.increment
+}
+
+func (ne *NumberEdit) SetIncrement(value float64) error {
+	if value == ne.increment {
+		return nil
+	}
+
+	if ne.stepMode == StepLogarithmic && value <= 1 {
+		return newError("increment must be greater than 1 for logarithmic stepping")
+	}
+
+	ne.increment = value
+
+	ne.updateSpinnerRange()
+
+	ne.incrementChangedPublisher.Publish()
+
 	return nil
 }
 
-func (ne *NumberEdit) TextSelection() (start, end int) {
-	return ne.edit.TextSelection()
+// IncrementFunc returns the function that provides the increment for each
+// step, or nil if the fixed increment is used.
+func (ne *NumberEdit) IncrementFunc() func(current float64, up bool) float64 {
+	return ne.incrementFunc
 }
 
-func (ne *NumberEdit) SetTextSelection(start, end int) {
-	ne.edit.SetTextSelection(start, end)
+// SetIncrementFunc sets a function that provides the increment for each step
+// of the spin button, the arrow keys and StepBy, based on the current value and
+// the direction, e.g. for coarser steps at larger values.
+//
+// It takes precedence over the step mode and caret relative stepping, but not
+// over allowed values. Pass nil to use the fixed increment again.
+func (ne *NumberEdit) SetIncrementFunc(f func(current float64, up bool) float64) {
+	ne.incrementFunc = f
 }
 
-func (ne *NumberEdit) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintptr {
-	if ne.hWndUpDown != 0 {
-		switch msg {
-		case WM_COMMAND:
-			switch HIWORD(uint32(wParam)) {
-			case EN_CHANGE:
-				value := ne.Value()
-				if math.Abs(value-ne.oldValue) < math.SmallestNonzeroFloat64 {
-					break
-				}
+func (ne *NumberEdit) IncrementChanged() *Event {
+	return ne.incrementChangedPublisher.Event()
+}
 
-				ne.oldValue = value
+// StepMode returns how the spinner changes the value of the *NumberEdit.
+func (ne *NumberEdit) StepMode() StepMode {
+	return ne.stepMode
+}
 
-				ne.valueChangedPublisher.Publish()
-			}
+// SetStepMode sets how the spinner changes the value of the *NumberEdit.
+//
+// In StepLogarithmic mode, the increment is used as factor and must be greater
+// than 1, so set it before switching to that mode. Stepping up from zero goes to
+// the smallest value that can be displayed with the configured decimals,
+// stepping towards zero from there goes to zero. Negative values step away from
+// zero when stepping down. Stepping is clamped to the range of the *NumberEdit.
+func (ne *NumberEdit) SetStepMode(mode StepMode) error {
+	if mode != StepLinear && mode != StepLogarithmic {
+		return newError("invalid step mode")
+	}
 
-		case WM_NOTIFY:
-			switch ((*NMHDR)(unsafe.Pointer(lParam))).Code {
-			case UDN_DELTAPOS:
-				nmud := (*NMUPDOWN)(unsafe.Pointer(lParam))
-				val := ne.Value()
-				val -= float64(nmud.IDelta) * ne.increment
-				ne.SetValue(val)
-			}
+	if mode == StepLogarithmic && ne.increment <= 1 {
+		return newError("increment must be greater than 1 for logarithmic stepping")
+	}
 
-		case WM_SIZE, WM_SIZING:
-			cb := ne.ClientBounds()
-			if err := ne.edit.SetBounds(cb); err != nil {
-				break
-			}
-			SendMessage(ne.hWndUpDown, UDM_SETBUDDY, uintptr(ne.edit.hWnd), 0)
+	ne.stepMode = mode
+
+	return nil
+}
+
+// CaretRelativeStepping returns if stepping changes the digit at the caret
+// instead of using the increment.
+func (ne *NumberEdit) CaretRelativeStepping() bool {
+	return ne.caretRelativeStepping
+}
+
+// SetCaretRelativeStepping sets if stepping changes the digit at the caret
+// instead of using the increment.
+//
+// The digit left of the caret is stepped, e.g. the tens for "1|2.34" and the
+// hundredths for "12.34|". If there is no digit left of the caret, the digit
+// right of it is stepped. Without a digit next to the caret, the increment is
+// used.
+func (ne *NumberEdit) SetCaretRelativeStepping(value bool) {
+	ne.caretRelativeStepping = value
+}
+
+// caretDigitExponent returns the power of ten of the digit to step, according
+// to the caret position.
+func (ne *NumberEdit) caretDigitExponent() (exp int, ok bool) {
+	if ne.formatFunc != nil || ne.fractionMode {
+		// The digits of custom formatted text need not be those of the value.
+		return 0, false
+	}
+
+	text := ne.edit.Text()
+	caret, _ := ne.edit.TextSelection()
+
+	utf16 := syscall.StringToUTF16(text)
+	if caret > len(utf16)-1 {
+		caret = len(utf16) - 1
+	}
+
+	s := canonicalFloatStringLocale(text, ne.numberLocale())
+	pos := len(canonicalFloatStringLocale(syscall.UTF16ToString(utf16[:caret]), ne.numberLocale()))
+
+	isDigit := func(i int) bool {
+		return i >= 0 && i < len(s) && s[i] >= '0' && s[i] <= '9'
+	}
+
+	if isDigit(pos - 1) {
+		pos--
+	} else if !isDigit(pos) {
+		return 0, false
+	}
+
+	dot := strings.Index(s, ".")
+	if dot == -1 {
+		dot = len(s)
+	}
+
+	if pos < dot {
+		return dot - pos - 1, true
+	}
+
+	return dot - pos, true
+}
+
+// stepDigit changes the value by steps times the power of ten exp, keeping the
+// caret at the same digit.
+func (ne *NumberEdit) stepDigit(steps, exp int) {
+	caret, _ := ne.edit.TextSelection()
+	fromEnd := len(syscall.StringToUTF16(ne.edit.Text())) - caret
+
+	if ne.ratMode {
+		n := exp
+		if n < 0 {
+			n = -n
+		}
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+
+		delta := new(big.Rat)
+		if exp < 0 {
+			delta.SetFrac(big.NewInt(int64(steps)), pow)
+		} else {
+			delta.SetInt(pow.Mul(pow, big.NewInt(int64(steps))))
+		}
+
+		ne.SetRatValue(ne.clampRatToSpinRange(delta.Add(ne.RatValue(), delta)))
+	} else {
+		ne.setValue(ne.clampToSpinRange(ne.Value() + float64(steps)*math.Pow10(exp)))
+	}
+
+	caret = len(syscall.StringToUTF16(ne.edit.Text())) - fromEnd
+	if caret < 0 {
+		caret = 0
+	}
+	ne.edit.SetTextSelection(caret, caret)
+}
+
+// AllowedValues returns the values the *NumberEdit is restricted to, or nil.
+func (ne *NumberEdit) AllowedValues() []float64 {
+	values := make([]float64, len(ne.allowedValues))
+	copy(values, ne.allowedValues)
+
+	return values
+}
+
+// SetAllowedValues restricts the *NumberEdit to the specified values, e.g. 8,
+// 16, 32 and 64.
+//
+// Stepping jumps to the next or previous allowed value, while SetValue and
+// typed input, when the *NumberEdit loses the focus, snap to the nearest
+// allowed value. An empty slice removes the restriction.
+func (ne *NumberEdit) SetAllowedValues(values []float64) error {
+	if len(values) == 0 {
+		ne.allowedValues = nil
+		return nil
+	}
+
+	ne.allowedValues = make([]float64, len(values))
+	copy(ne.allowedValues, values)
+	sort.Float64s(ne.allowedValues)
+
+	if ne.isNull {
+		return nil
+	}
+
+	return ne.setValue(ne.Value())
+}
+
+// snapToAllowedValue returns the allowed value nearest to value.
+func (ne *NumberEdit) snapToAllowedValue(value float64) float64 {
+	values := ne.allowedValues
+	if len(values) == 0 {
+		return value
+	}
+
+	i := sort.SearchFloat64s(values, value)
+	switch {
+	case i == 0:
+		return values[0]
+
+	case i == len(values):
+		return values[i-1]
+
+	case values[i]-value < value-values[i-1]:
+		return values[i]
+	}
+
+	return values[i-1]
+}
+
+// stepAllowedValues moves steps allowed values up, or down for negative steps.
+func (ne *NumberEdit) stepAllowedValues(steps int) {
+	values := ne.allowedValues
+	value := ne.Value()
+
+	var i int
+	if steps > 0 {
+		// index of the first value greater than the current one
+		i = sort.Search(len(values), func(j int) bool {
+			return values[j] > value
+		}) + steps - 1
+	} else {
+		// index of the last value less than the current one
+		i = sort.SearchFloat64s(values, value) + steps
+	}
+
+	if i < 0 {
+		i = 0
+	} else if i >= len(values) {
+		i = len(values) - 1
+	}
+
+	ne.setValue(values[i])
+}
+
+// SetBaseline makes the current value the baseline of the *NumberEdit, that
+// IsDirty compares the value to, e.g. after loading or saving a form.
+//
+// Until SetBaseline is called, the baseline is the initial value 0.
+func (ne *NumberEdit) SetBaseline() {
+	ne.baseline = ne.Value()
+	ne.baselineNull = ne.isNull
+
+	ne.updateDirty()
+}
+
+// IsDirty returns if the value of the *NumberEdit differs from its baseline.
+func (ne *NumberEdit) IsDirty() bool {
+	return ne.dirty
+}
+
+// DirtyChanged returns the event that is published when the value of the
+// *NumberEdit starts or stops differing from its baseline.
+func (ne *NumberEdit) DirtyChanged() *Event {
+	return ne.dirtyChangedPublisher.Event()
+}
+
+func (ne *NumberEdit) updateDirty() {
+	dirty := ne.isNull != ne.baselineNull || (!ne.isNull && ne.Value() != ne.baseline)
+	if dirty == ne.dirty {
+		return
+	}
+
+	ne.dirty = dirty
+
+	ne.dirtyChangedPublisher.Publish()
+}
+
+// TrackStats returns if the *NumberEdit tracks the minimum and maximum of the
+// values entered.
+func (ne *NumberEdit) TrackStats() bool {
+	return ne.trackStats
+}
+
+// SetTrackStats sets if the *NumberEdit tracks the minimum and maximum of the
+// values entered. Tracking is off by default. Enabling it resets the stats.
+//
+// A value counts as entered when it is set, stepped to or when the user leaves
+// the *NumberEdit after typing it, not for every keystroke.
+func (ne *NumberEdit) SetTrackStats(value bool) {
+	if value && !ne.trackStats {
+		ne.ResetStats()
+	}
+
+	ne.trackStats = value
+}
+
+// MinEntered returns the minimum value entered since tracking was enabled or
+// ResetStats was called, or 0 if no value was entered.
+func (ne *NumberEdit) MinEntered() float64 {
+	return ne.minEntered
+}
+
+// MaxEntered returns the maximum value entered since tracking was enabled or
+// ResetStats was called, or 0 if no value was entered.
+func (ne *NumberEdit) MaxEntered() float64 {
+	return ne.maxEntered
+}
+
+// ResetStats forgets the minimum and maximum of the values entered.
+func (ne *NumberEdit) ResetStats() {
+	ne.hasStats = false
+	ne.minEntered = 0
+	ne.maxEntered = 0
+}
+
+// commit is called with the value of the *NumberEdit whenever it was
+// committed, i.e. set, stepped or left by the user, as opposed to typed.
+func (ne *NumberEdit) commit(value float64) {
+	ne.updateStats(value)
+
+	if !ne.silent {
+		ne.committedPublisher.Publish()
+	}
+}
+
+// updateStats takes value, that was just committed, into account for the stats,
+// if they are tracked.
+func (ne *NumberEdit) updateStats(value float64) {
+	if !ne.trackStats || ne.isNull {
+		return
+	}
+
+	if !ne.hasStats {
+		ne.hasStats = true
+		ne.minEntered, ne.maxEntered = value, value
+		return
+	}
+
+	ne.minEntered = math.Min(ne.minEntered, value)
+	ne.maxEntered = math.Max(ne.maxEntered, value)
+}
+
+// ValidationMessage returns the message shown when the *NumberEdit loses the
+// focus with an invalid value.
+func (ne *NumberEdit) ValidationMessage() string {
+	return ne.validationMessage
+}
+
+// SetValidationMessage sets the message shown in a balloon tip when the
+// *NumberEdit loses the focus with a value that is not a number or out of
+// range, e.g. "Enter a number from 0 to 100.".
+//
+// The balloon tip is hidden as soon as the value is valid. An empty message
+// disables the balloon tip.
+func (ne *NumberEdit) SetValidationMessage(message string) {
+	ne.validationMessage = message
+
+	if message == "" {
+		ne.edit.hideBalloonTip()
+	}
+}
+
+// valid returns if the text of the *NumberEdit is a number within the range.
+func (ne *NumberEdit) valid() bool {
+	if ne.isNull {
+		return true
+	}
+
+	value, err := ne.parseText(ne.edit.Text())
+	if err != nil {
+		return false
+	}
+
+	return value >= ne.MinValue() && value <= ne.MaxValue()
+}
+
+// SpinButtonVisible returns if the spin button of the *NumberEdit is visible.
+func (ne *NumberEdit) SpinButtonVisible() bool {
+	return !ne.spinButtonHidden
+}
+
+// SetSpinButtonVisible sets if the spin button of the *NumberEdit is visible.
+//
+// The Up and Down arrow keys step the value regardless.
+func (ne *NumberEdit) SetSpinButtonVisible(visible bool) {
+	if visible == !ne.spinButtonHidden {
+		return
+	}
+
+	ne.spinButtonHidden = !visible
+
+	if visible {
+		ShowWindow(ne.hWndUpDown, SW_SHOW)
+	} else {
+		ShowWindow(ne.hWndUpDown, SW_HIDE)
+	}
+
+	ne.updateEditBounds()
+}
+
+// SpinnerTabStop returns if the spin button of the *NumberEdit can be focused
+// using the tab key.
+func (ne *NumberEdit) SpinnerTabStop() bool {
+	return ne.spinnerTabStop
+}
+
+// SetSpinnerTabStop sets if the spin button of the *NumberEdit can be focused
+// using the tab key. It can not by default, so tabbing moves from the edit
+// straight to the next widget.
+func (ne *NumberEdit) SetSpinnerTabStop(tabStop bool) error {
+	if tabStop == ne.spinnerTabStop {
+		return nil
+	}
+
+	style := uint32(GetWindowLong(ne.hWndUpDown, GWL_STYLE))
+	if style == 0 {
+		return lastError("GetWindowLong")
+	}
+
+	if tabStop {
+		style |= WS_TABSTOP
+	} else {
+		style &^= WS_TABSTOP
+	}
+
+	SetLastError(0)
+	if SetWindowLong(ne.hWndUpDown, GWL_STYLE, int32(style)) == 0 {
+		return lastError("SetWindowLong")
+	}
+
+	ne.spinnerTabStop = tabStop
+
+	return nil
+}
+
+func (ne *NumberEdit) updateEditBounds() {
+	cb := ne.ClientBounds()
+	if err := ne.edit.SetBounds(cb); err != nil {
+		return
+	}
+
+	if !ne.spinButtonHidden {
+		// Makes the up-down control shrink the edit and align itself to it.
+		SendMessage(ne.hWndUpDown, UDM_SETBUDDY, uintptr(ne.edit.hWnd), 0)
+	}
+}
+
+func (ne *NumberEdit) updateSpinnerRange() {
+	var steps float64
+	if ne.increment > 0 {
+		min, max := ne.SpinRange()
+		steps = math.Min((max-min)/ne.increment, math.MaxInt32)
+	}
+
+	// The range is inverted like the default range of an up-down control, so
+	// UDN_DELTAPOS keeps reporting negative deltas for the up arrow.
+	SendMessage(ne.hWndUpDown, UDM_SETRANGE32, uintptr(int32(steps)), 0)
+}
+
+func (ne *NumberEdit) MinValue() float64 {
+	return ne.edit.Validator().(*NumberValidator).MinValue()
+}
+
+func (ne *NumberEdit) MaxValue() float64 {
+	return ne.edit.Validator().(*NumberValidator).MaxValue()
+}
+
+func (ne *NumberEdit) SetRange(min, max float64) error {
+	if err := ne.edit.Validator().(*NumberValidator).SetRange(min, max); err != nil {
+		return err
+	}
+
+	ne.updateSpinnerRange()
+
+	return nil
+}
+
+// SpinRange returns the range the spin button and arrow keys step within. It
+// is the value range, unless SetSpinRange was called.
+func (ne *NumberEdit) SpinRange() (min, max float64) {
+	if ne.hasSpinRange {
+		return ne.spinMin, ne.spinMax
+	}
+
+	return ne.MinValue(), ne.MaxValue()
+}
+
+// SetSpinRange sets the range the spin button and arrow keys step within,
+// independent from the range of values that can be typed in.
+func (ne *NumberEdit) SetSpinRange(min, max float64) error {
+	if min > max {
+		return newError("invalid range")
+	}
+
+	ne.spinMin, ne.spinMax = min, max
+	ne.hasSpinRange = true
+
+	ne.updateSpinnerRange()
+
+	return nil
+}
+
+func (ne *NumberEdit) clampToSpinRange(value float64) float64 {
+	min, max := ne.SpinRange()
+
+	return math.Max(min, math.Min(max, value))
+}
+
+func (ne *NumberEdit) clampRatToSpinRange(value *big.Rat) *big.Rat {
+	min, max := ne.SpinRange()
+
+	if minRat := new(big.Rat).SetFloat64(min); minRat != nil && value.Cmp(minRat) < 0 {
+		return minRat
+	}
+	if maxRat := new(big.Rat).SetFloat64(max); maxRat != nil && value.Cmp(maxRat) > 0 {
+		return maxRat
+	}
+
+	return value
+}
+
+func (ne *NumberEdit) Value() float64 {
+	if ne.isNull {
+		return 0
+	}
+
+	val, _ := ne.parseText(ne.edit.Text())
+	return val
+}
+
+// ValueWithError returns the value of the *NumberEdit like Value, but also
+// returns the error if its text can not be parsed, e.g. because it is empty.
+//
+// A null *NumberEdit has a value of 0 and no error, see IsNull.
+func (ne *NumberEdit) ValueWithError() (float64, error) {
+	if ne.isNull {
+		return 0, nil
+	}
+
+	return ne.parseText(ne.edit.Text())
+}
+
+// parseText parses s using the parse func, if one was set.
+func (ne *NumberEdit) parseText(s string) (float64, error) {
+	if ne.parseFunc != nil {
+		return ne.parseFunc(s)
+	}
+
+	if ne.fractionMode {
+		return parseFraction(s, ne.numberLocale())
+	}
+
+	return parseFloatLocale(s, ne.numberLocale())
+}
+
+// SetValue sets the value of the *NumberEdit. If ClampToRange is enabled, a
+// value outside the range is clamped to it and the ValueClamped event is
+// published.
+//
+// SetValue ends the rational mode started by SetRatValue, so data binding works
+// with float64 values again.
+func (ne *NumberEdit) SetValue(value float64) error {
+	ne.ratMode = false
+
+	return ne.setValue(value)
+}
+
+// setValue sets the value of the *NumberEdit like SetValue, but keeps the
+// rational mode, e.g. when stepping or reformatting the value.
+func (ne *NumberEdit) setValue(value float64) (err error) {
+	value = ne.snapToAllowedValue(value)
+
+	if ne.clampToRange {
+		if clamped := math.Max(ne.MinValue(), math.Min(ne.MaxValue(), value)); clamped != value {
+			requested := value
+
+			defer func() {
+				if err == nil {
+					ne.valueClampedPublisher.Publish(requested, clamped)
+				}
+			}()
+
+			value = clamped
+		}
+	}
+
+	var text string
+	prec := ne.Decimals()
+
+	if ne.formatFunc != nil {
+		text = ne.formatFunc(value)
+	} else if ne.fractionMode {
+		text = formatFraction(value, ne.maxDenominator)
+	} else if prec == 0 {
+		text = strconv.Itoa(int(value))
+	} else {
+		text, err = formatFloatLocale(value, prec, ne.numberLocale())
+		if err != nil {
+			return
+		}
+	}
+
+	ne.isNull = false
+
+	if err = ne.edit.SetText(text); err != nil {
+		return
+	}
+
+	ne.commit(ne.Value())
+
+	return
+}
+
+// SetValueSilent sets the value of the *NumberEdit like SetValue, but without
+// publishing ValueChanged, ValueChangedEx, ValueChangedF and
+// FormattedTextChanged, e.g. to avoid echoes when reconciling a two-way binding.
+// A range partner linked by LinkAsRange is not adjusted either.
+//
+// DirtyChanged and ValueClamped are still published, because they report the
+// state of the *NumberEdit rather than an edit of its value.
+func (ne *NumberEdit) SetValueSilent(value float64) error {
+	ne.silent = true
+	defer func() {
+		ne.silent = false
+	}()
+
+	return ne.SetValue(value)
+}
+
+// RatValue returns the value of the *NumberEdit as an exact *big.Rat.
+//
+// With a FormatFunc, ParseFunc or fraction mode, the text is parsed the same way
+// as for Value and the value is only as exact as a float64.
+func (ne *NumberEdit) RatValue() *big.Rat {
+	if !ne.ratFormat() {
+		value, err := ne.parseText(ne.edit.Text())
+		if err != nil {
+			return new(big.Rat)
+		}
+
+		return ratFromFloat(value)
+	}
+
+	r, err := parseRatLocale(ne.edit.Text(), ne.numberLocale())
+	if err != nil {
+		return new(big.Rat)
+	}
+
+	return r
+}
+
+// SetRatValue sets the value of the *NumberEdit from a *big.Rat, formatted at
+// the configured number of decimals. Like SetValue, it snaps the value to the
+// allowed values and clamps it to the range, if ClampToRange is enabled.
+//
+// After calling SetRatValue, the spinner steps and data binding work with
+// *big.Rat values, to avoid float rounding errors e.g. in currency fields, until
+// SetValue is called. With a FormatFunc, ParseFunc, fraction mode or allowed
+// values, the value goes through the float64 pipeline of SetValue instead.
+func (ne *NumberEdit) SetRatValue(value *big.Rat) (err error) {
+	if value == nil {
+		return newError("value must not be nil")
+	}
+
+	ne.ratMode = true
+
+	if !ne.ratFormat() || len(ne.allowedValues) > 0 {
+		f, _ := value.Float64()
+		return ne.setValue(f)
+	}
+
+	if ne.clampToRange {
+		var clamped *big.Rat
+		if min := ratFromFloat(ne.MinValue()); value.Cmp(min) < 0 {
+			clamped = min
+		} else if max := ratFromFloat(ne.MaxValue()); value.Cmp(max) > 0 {
+			clamped = max
+		}
+
+		if clamped != nil {
+			requested, _ := value.Float64()
+			limit, _ := clamped.Float64()
+
+			defer func() {
+				if err == nil {
+					ne.valueClampedPublisher.Publish(requested, limit)
+				}
+			}()
+
+			value = clamped
+		}
+	}
+
+	text, err := formatRatLocale(value, ne.Decimals(), ne.numberLocale())
+	if err != nil {
+		return err
+	}
+
+	ne.isNull = false
+
+	if err := ne.edit.SetText(text); err != nil {
+		return err
+	}
+
+	ne.commit(ne.Value())
+
+	return nil
+}
+
+// ratFormat returns if the text of the *NumberEdit is a plain decimal number,
+// that can be parsed and formatted exactly as *big.Rat.
+func (ne *NumberEdit) ratFormat() bool {
+	return ne.formatFunc == nil && ne.parseFunc == nil && !ne.fractionMode
+}
+
+// ratIncrement returns the increment as exact decimal, e.g. 0.01 instead of
+// the nearest binary fraction.
+func (ne *NumberEdit) ratIncrement() *big.Rat {
+	return ratFromFloat(ne.increment)
+}
+
+// ratFromFloat returns value as the exact decimal it is displayed as, e.g. 0.01
+// instead of the nearest binary fraction.
+func ratFromFloat(value float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	if !ok {
+		return new(big.Rat).SetFloat64(value)
+	}
+
+	return r
+}
+
+// Nullable returns if the *NumberEdit can be set to a null value.
+func (ne *NumberEdit) Nullable() bool {
+	return ne.nullable
+}
+
+// SetNullable sets if the *NumberEdit can be set to a null value.
+func (ne *NumberEdit) SetNullable(nullable bool) error {
+	if !nullable && ne.isNull {
+		if err := ne.setValue(0); err != nil {
+			return err
+		}
+	}
+
+	ne.nullable = nullable
+
+	return nil
+}
+
+// IsNull returns if the *NumberEdit currently holds no value.
+//
+// While it is null, Value returns 0.
+func (ne *NumberEdit) IsNull() bool {
+	return ne.isNull
+}
+
+// SetNull clears the value of a nullable *NumberEdit, showing the null text.
+//
+// The *NumberEdit stops being null when the user enters a value or a value is
+// set by calling SetValue or SetRatValue.
+func (ne *NumberEdit) SetNull() error {
+	if !ne.nullable {
+		return newError("NumberEdit is not nullable")
+	}
+
+	ne.isNull = true
+
+	return ne.showNullText()
+}
+
+// NullText returns the text displayed while the *NumberEdit is null.
+func (ne *NumberEdit) NullText() string {
+	return ne.nullText
+}
+
+// SetNullText sets the text displayed while the *NumberEdit is null, e.g.
+// "not set".
+//
+// The null text is removed as soon as the inner edit receives the focus, so
+// the user starts typing into an empty edit.
+func (ne *NumberEdit) SetNullText(text string) error {
+	ne.nullText = text
+
+	if ne.isNull {
+		return ne.showNullText()
+	}
+
+	return nil
+}
+
+func (ne *NumberEdit) showNullText() error {
+	if GetFocus() == ne.edit.hWnd {
+		return ne.edit.SetText("")
+	}
+
+	return ne.edit.SetText(ne.nullText)
+}
+
+func (ne *NumberEdit) ValueChanged() *Event {
+	return ne.valueChangedPublisher.Event()
+}
+
+// ValueChangedEx returns an event that is published after the value changed,
+// passing the old and the new value to its handlers.
+func (ne *NumberEdit) ValueChangedEx() *Float64PairEvent {
+	return ne.valueChangedExPublisher.Event()
+}
+
+// ValueChangedF returns an event that is published after the value changed,
+// passing the new value to its handlers.
+func (ne *NumberEdit) ValueChangedF() *Float64Event {
+	return ne.valueChangedFPublisher.Event()
+}
+
+// BindToFloat64 binds the value of the *NumberEdit to the float64 ptr points
+// to, as a lightweight alternative to a DataBinder for simple cases.
+//
+// The value is initialized from ptr and written back to it whenever it changes.
+// If refresh is not nil, the value is read from ptr again each time refresh is
+// published, e.g. after the application changed it. Pass a nil ptr to remove
+// the binding.
+func (ne *NumberEdit) BindToFloat64(ptr *float64, refresh *Event) error {
+	ne.unbindFloat64()
+
+	if ptr == nil {
+		return nil
+	}
+
+	if err := ne.SetValue(*ptr); err != nil {
+		return err
+	}
+
+	ne.boundFloat64 = ptr
+	ne.boundValueChangedHandle = ne.ValueChanged().Attach(func() {
+		*ptr = ne.Value()
+	})
+
+	if refresh != nil {
+		ne.boundRefresh = refresh
+		ne.boundRefreshHandle = refresh.Attach(func() {
+			ne.SetValue(*ptr)
+		})
+	}
+
+	return nil
+}
+
+func (ne *NumberEdit) unbindFloat64() {
+	if ne.boundFloat64 == nil {
+		return
+	}
+
+	ne.ValueChanged().Detach(ne.boundValueChangedHandle)
+	ne.boundFloat64 = nil
+
+	if ne.boundRefresh != nil {
+		ne.boundRefresh.Detach(ne.boundRefreshHandle)
+		ne.boundRefresh = nil
+	}
+}
+
+// LinkAsRange keeps the values of minEdit and maxEdit ordered, e.g. for a range
+// filter. If the value of minEdit is committed above the one of maxEdit, maxEdit
+// is set to it and vice versa.
+//
+// A value is committed by SetValue, by stepping or when the user leaves the
+// edit, so a partially typed value does not push the other edit around.
+func LinkAsRange(minEdit, maxEdit *NumberEdit) {
+	var syncing bool
+
+	minEdit.committedPublisher.Event().Attach(func() {
+		if syncing {
+			return
+		}
+		syncing = true
+		defer func() {
+			syncing = false
+		}()
+
+		if min := minEdit.Value(); min > maxEdit.Value() {
+			maxEdit.SetValue(min)
+		}
+	})
+
+	maxEdit.committedPublisher.Event().Attach(func() {
+		if syncing {
+			return
+		}
+		syncing = true
+		defer func() {
+			syncing = false
+		}()
+
+		if max := maxEdit.Value(); max < minEdit.Value() {
+			minEdit.SetValue(max)
+		}
+	})
+}
+
+// ClampToRange returns whether SetValue, and leaving the *NumberEdit with a
+// typed value, clamp a value outside the range to it.
+func (ne *NumberEdit) ClampToRange() bool {
+	return ne.clampToRange
+}
+
+// SetClampToRange sets whether SetValue, and leaving the *NumberEdit with a
+// typed value, clamp a value outside the range to it. It is disabled by
+// default, so values are only checked against the range by validation.
+func (ne *NumberEdit) SetClampToRange(value bool) {
+	ne.clampToRange = value
+}
+
+// ValueClamped returns an event that is published after SetValue, or leaving
+// the *NumberEdit with a typed value, adjusted the value to fit the range,
+// passing the requested and the clamped value to its handlers, e.g. to tell the
+// user about it. This only happens if ClampToRange is enabled.
+func (ne *NumberEdit) ValueClamped() *Float64PairEvent {
+	return ne.valueClampedPublisher.Event()
+}
+
+// FormattedTextChanged returns an event that is published whenever the text
+// displayed in the *NumberEdit changed, passing the text including separators
+// to its handlers, e.g. to echo it in a status bar.
+func (ne *NumberEdit) FormattedTextChanged() *StringEvent {
+	return ne.formattedTextPublisher.Event()
+}
+
+func (ne *NumberEdit) SetFocus() error {
+	if SetFocus(ne.edit.hWnd) == 0 {
+		return lastError("SetFocus")
+	}
+
+	return nil
+}
+
+func (ne *NumberEdit) TextSelection() (start, end int) {
+	return ne.edit.TextSelection()
+}
+
+func (ne *NumberEdit) SetTextSelection(start, end int) {
+	ne.edit.SetTextSelection(start, end)
+}
+
+func (ne *NumberEdit) TextSelectionChanged() *Event {
+	return ne.edit.TextSelectionChanged()
+}
+
+// EnterKeyPressed returns an event that is published when the Enter key is
+// pressed in the *NumberEdit.
+func (ne *NumberEdit) EnterKeyPressed() *Event {
+	return ne.edit.ReturnPressed()
+}
+
+// step increases the value by steps increments, or decreases it for negative
+// steps.
+func (ne *NumberEdit) step(steps int) {
+	if len(ne.allowedValues) > 0 {
+		ne.stepAllowedValues(steps)
+		return
+	}
+
+	if ne.caretRelativeStepping && ne.incrementFunc == nil {
+		if exp, ok := ne.caretDigitExponent(); ok {
+			ne.stepDigit(steps, exp)
+			return
+		}
+	}
+
+	ne.stepBy(float64(steps))
+}
+
+// StepBy changes the value by delta increments, like the spin button does, e.g.
+// for external +/- buttons. The result is clamped to the spin range.
+//
+// In logarithmic step mode, the value is multiplied by the increment to the
+// power of delta instead. If there are allowed values, delta must be a whole
+// number of values to move by.
+func (ne *NumberEdit) StepBy(delta float64) error {
+	if math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return newError("delta must be a finite number")
+	}
+
+	if len(ne.allowedValues) > 0 {
+		if delta != math.Trunc(delta) {
+			return newError("delta must be a whole number with allowed values")
+		}
+
+		ne.stepAllowedValues(int(delta))
+		return nil
+	}
+
+	return ne.stepBy(delta)
+}
+
+// stepBy changes the value by delta increments, clamped to the spin range.
+func (ne *NumberEdit) stepBy(delta float64) error {
+	if ne.incrementFunc != nil {
+		return ne.stepIncrementFunc(delta)
+	}
+
+	if ne.fractionMode {
+		return ne.setValue(ne.clampToSpinRange(ne.Value() + delta/float64(ne.maxDenominator)))
+	}
+
+	if ne.stepMode == StepLogarithmic {
+		return ne.stepLogarithmic(delta)
+	}
+
+	if ne.ratMode {
+		d := new(big.Rat).Mul(ne.ratIncrement(), new(big.Rat).SetFloat64(delta))
+		return ne.SetRatValue(ne.clampRatToSpinRange(d.Add(ne.RatValue(), d)))
+	}
+
+	return ne.setValue(ne.clampToSpinRange(ne.Value() + delta*ne.increment))
+}
+
+// stepIncrementFunc changes the value by delta steps, asking the increment func
+// for the size of each one. A fraction of a step takes that fraction of the
+// increment.
+func (ne *NumberEdit) stepIncrementFunc(delta float64) error {
+	up := delta > 0
+	value := ne.Value()
+
+	for remaining := math.Abs(delta); remaining > 0; remaining-- {
+		increment := ne.incrementFunc(value, up) * math.Min(remaining, 1)
+
+		if up {
+			value += increment
+		} else {
+			value -= increment
+		}
+	}
+
+	value = ne.clampToSpinRange(value)
+
+	if ne.ratMode {
+		return ne.SetRatValue(new(big.Rat).SetFloat64(value))
+	}
+
+	return ne.setValue(value)
+}
+
+func (ne *NumberEdit) stepLogarithmic(delta float64) error {
+	if ne.increment <= 1 {
+		return newError("increment must be greater than 1 for logarithmic stepping")
+	}
+
+	// The smallest magnitude that can be displayed. Multiplying never leaves
+	// zero, so stepping starts from there.
+	smallest := math.Pow10(-ne.Decimals())
+
+	var val float64
+	if value := ne.Value(); value == 0 {
+		val = math.Copysign(smallest, delta)
+	} else {
+		if value < 0 {
+			// Stepping up moves a negative value towards zero.
+			delta = -delta
+		}
+
+		magnitude := math.Max(math.Abs(value), smallest) * math.Pow(ne.increment, delta)
+		if magnitude >= smallest {
+			val = math.Copysign(magnitude, value)
+		}
+	}
+
+	val = ne.clampToSpinRange(val)
+
+	if ne.ratMode {
+		return ne.SetRatValue(new(big.Rat).SetFloat64(val))
+	}
+
+	return ne.setValue(val)
+}
+
+func (ne *NumberEdit) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	if ne.hWndUpDown != 0 {
+		switch msg {
+		case WM_COMMAND:
+			switch HIWORD(uint32(wParam)) {
+			case EN_SETFOCUS:
+				if ne.isNull && ne.nullText != "" {
+					ne.edit.SetText("")
+				}
+
+			case EN_KILLFOCUS:
+				if ne.isNull && ne.edit.Text() == "" {
+					ne.edit.SetText(ne.nullText)
+				}
+
+				if len(ne.allowedValues) > 0 && !ne.isNull {
+					ne.setValue(ne.Value())
+				} else if ne.clampToRange && !ne.isNull {
+					// setValue clamps typed values that are out of range.
+					if value, err := ne.parseText(ne.edit.Text()); err == nil &&
+						(value < ne.MinValue() || value > ne.MaxValue()) {
+
+						ne.setValue(value)
+					}
+				}
+
+				if value, err := ne.parseText(ne.edit.Text()); err == nil {
+					ne.commit(value)
+				}
+
+				if ne.validationMessage != "" && !ne.valid() {
+					ne.edit.showBalloonTip("Invalid value", ne.validationMessage)
+				}
+
+			case EN_CHANGE:
+				if ne.isNull {
+					if text := ne.edit.Text(); text != "" && text != ne.nullText {
+						ne.isNull = false
+					}
+				}
+
+				if ne.validationMessage != "" && ne.valid() {
+					ne.edit.hideBalloonTip()
+				}
+
+				ne.updateDirty()
+
+				if !ne.silent {
+					ne.formattedTextPublisher.Publish(ne.edit.Text())
+				}
+
+				value := ne.Value()
+				if math.Abs(value-ne.oldValue) < math.SmallestNonzeroFloat64 {
+					break
+				}
+
+				oldValue := ne.oldValue
+				ne.oldValue = value
+
+				if ne.silent {
+					break
+				}
+
+				ne.valueChangedPublisher.Publish()
+				ne.valueChangedExPublisher.Publish(oldValue, value)
+				ne.valueChangedFPublisher.Publish(value)
+			}
+
+		case WM_CONTEXTMENU:
+			if HWND(wParam) == ne.hWndUpDown && ne.ContextMenu() != nil {
+				TrackPopupMenuEx(
+					ne.ContextMenu().hMenu,
+					TPM_NOANIMATION,
+					GET_X_LPARAM(lParam),
+					GET_Y_LPARAM(lParam),
+					rootWidget(ne).BaseWidget().hWnd,
+					nil)
+				return 0
+			}
+
+		case WM_NOTIFY:
+			switch ((*NMHDR)(unsafe.Pointer(lParam))).Code {
+			case UDN_DELTAPOS:
+				nmud := (*NMUPDOWN)(unsafe.Pointer(lParam))
+				// Negative deltas come from the up arrow.
+				ne.step(-int(nmud.IDelta))
+			}
+
+		case WM_SIZE, WM_SIZING:
+			ne.updateEditBounds()
 		}
 	}
 
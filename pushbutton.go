@@ -32,6 +32,11 @@ func (*PushButton) LayoutFlags() LayoutFlags {
 }
 
 func (pb *PushButton) MinSizeHint() Size {
+	if pb.hIcon != 0 {
+		// Leave room for the border and focus rectangle around the icon.
+		return Size{pb.iconSize.Width + 12, pb.iconSize.Height + 10}
+	}
+
 	var s Size
 
 	pb.SendMessage(BCM_GETIDEALSIZE, 0, uintptr(unsafe.Pointer(&s)))
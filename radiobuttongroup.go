@@ -0,0 +1,82 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import . "github.com/lxn/go-winapi"
+
+// RadioButtonGroup groups RadioButtons, so exactly one of them is checked at a
+// time, and tracks which one that is.
+//
+// The first *RadioButton added gets the WS_GROUP style, so the arrow keys move
+// between the buttons of the group. The buttons should be created one after
+// the other, without other widgets in between.
+type RadioButtonGroup struct {
+	buttons                      []*RadioButton
+	currentIndex                 int
+	currentIndexChangedPublisher EventPublisher
+}
+
+// NewRadioButtonGroup returns a new, empty *RadioButtonGroup.
+func NewRadioButtonGroup() *RadioButtonGroup {
+	return &RadioButtonGroup{currentIndex: -1}
+}
+
+// Add adds rb to the *RadioButtonGroup.
+func (g *RadioButtonGroup) Add(rb *RadioButton) error {
+	if len(g.buttons) == 0 {
+		if err := rb.ensureStyleBits(WS_GROUP, true); err != nil {
+			return err
+		}
+	}
+
+	index := len(g.buttons)
+	g.buttons = append(g.buttons, rb)
+
+	rb.Clicked().Attach(func() {
+		g.SetCurrentIndex(index)
+	})
+
+	if rb.Checked() {
+		g.SetCurrentIndex(index)
+	}
+
+	return nil
+}
+
+// Buttons returns the RadioButtons of the *RadioButtonGroup.
+func (g *RadioButtonGroup) Buttons() []*RadioButton {
+	return g.buttons
+}
+
+// CurrentIndex returns the index of the checked *RadioButton, or -1 if none is
+// checked.
+func (g *RadioButtonGroup) CurrentIndex() int {
+	return g.currentIndex
+}
+
+// SetCurrentIndex checks the *RadioButton at index and unchecks the others.
+// Pass -1 to uncheck all of them.
+func (g *RadioButtonGroup) SetCurrentIndex(index int) error {
+	if index < -1 || index >= len(g.buttons) {
+		return newError("index out of range")
+	}
+
+	for i, rb := range g.buttons {
+		rb.SetChecked(i == index)
+	}
+
+	if index != g.currentIndex {
+		g.currentIndex = index
+		g.currentIndexChangedPublisher.Publish()
+	}
+
+	return nil
+}
+
+// CurrentIndexChanged returns the event that is published after another
+// *RadioButton was checked.
+func (g *RadioButtonGroup) CurrentIndexChanged() *Event {
+	return g.currentIndexChangedPublisher.Event()
+}
@@ -0,0 +1,118 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+// ReorderedListModel is a ListModel that wraps another ListModel and presents
+// its items in a custom order, e.g. most recently used first.
+type ReorderedListModel struct {
+	ListModelBase
+	inner                    ListModel
+	order                    []int
+	itemsResetHandlerHandle  int
+	itemChangedHandlerHandle int
+}
+
+// NewReorderedListModel returns a new *ReorderedListModel that presents the
+// item order[index] of inner at index. A nil order presents the items in their
+// original order.
+func NewReorderedListModel(inner ListModel, order []int) (*ReorderedListModel, error) {
+	m := &ReorderedListModel{inner: inner}
+
+	if err := m.setOrder(order); err != nil {
+		return nil, err
+	}
+
+	m.itemsResetHandlerHandle = inner.ItemsReset().Attach(func() {
+		if len(m.order) != inner.ItemCount() {
+			// The order does not fit the items anymore.
+			m.order = identityOrder(inner.ItemCount())
+		}
+
+		m.PublishItemsReset()
+	})
+
+	m.itemChangedHandlerHandle = inner.ItemChanged().Attach(func(innerIndex int) {
+		for index, i := range m.order {
+			if i == innerIndex {
+				m.PublishItemChanged(index)
+				break
+			}
+		}
+	})
+
+	return m, nil
+}
+
+// Dispose detaches the *ReorderedListModel from the events of the inner model.
+func (m *ReorderedListModel) Dispose() {
+	m.inner.ItemsReset().Detach(m.itemsResetHandlerHandle)
+	m.inner.ItemChanged().Detach(m.itemChangedHandlerHandle)
+}
+
+// Inner returns the wrapped ListModel.
+func (m *ReorderedListModel) Inner() ListModel {
+	return m.inner
+}
+
+// Order returns the indexes of the inner model in presentation order.
+func (m *ReorderedListModel) Order() []int {
+	return m.order
+}
+
+// SetOrder sets the indexes of the inner model in presentation order and
+// publishes the ItemsReset event. The order must be a permutation of the
+// indexes of the inner model, or nil for their original order.
+func (m *ReorderedListModel) SetOrder(order []int) error {
+	if err := m.setOrder(order); err != nil {
+		return err
+	}
+
+	m.PublishItemsReset()
+
+	return nil
+}
+
+func (m *ReorderedListModel) setOrder(order []int) error {
+	count := m.inner.ItemCount()
+
+	if order == nil {
+		m.order = identityOrder(count)
+		return nil
+	}
+
+	if len(order) != count {
+		return newError("order must contain every index exactly once")
+	}
+
+	seen := make([]bool, count)
+	for _, i := range order {
+		if i < 0 || i >= count || seen[i] {
+			return newError("order must contain every index exactly once")
+		}
+
+		seen[i] = true
+	}
+
+	m.order = order
+
+	return nil
+}
+
+func (m *ReorderedListModel) ItemCount() int {
+	return len(m.order)
+}
+
+func (m *ReorderedListModel) Value(index int) interface{} {
+	return m.inner.Value(m.order[index])
+}
+
+func identityOrder(count int) []int {
+	order := make([]int, count)
+	for i := range order {
+		order[i] = i
+	}
+
+	return order
+}
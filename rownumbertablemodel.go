@@ -0,0 +1,141 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+// RowNumberTableModel is a TableModel that wraps another TableModel and
+// presents its columns after a leading "#" column, that holds the row number.
+//
+// A *RowNumberTableModel does not implement Sorter. Use a
+// *SortableRowNumberTableModel to wrap a model that does.
+type RowNumberTableModel struct {
+	TableModelBase
+	inner                     TableModel
+	rowsResetHandlerHandle    int
+	rowChangedHandlerHandle   int
+	columnsResetHandlerHandle int
+}
+
+// NewRowNumberTableModel returns a new *RowNumberTableModel that wraps inner.
+func NewRowNumberTableModel(inner TableModel) *RowNumberTableModel {
+	m := &RowNumberTableModel{inner: inner}
+
+	m.rowsResetHandlerHandle = inner.RowsReset().Attach(func() {
+		m.PublishRowsReset()
+	})
+
+	m.rowChangedHandlerHandle = inner.RowChanged().Attach(func(row int) {
+		m.PublishRowChanged(row)
+	})
+
+	m.columnsResetHandlerHandle = inner.ColumnsReset().Attach(func() {
+		m.PublishColumnsReset()
+	})
+
+	return m
+}
+
+// Dispose detaches the *RowNumberTableModel from the events of the inner model.
+func (m *RowNumberTableModel) Dispose() {
+	m.inner.RowsReset().Detach(m.rowsResetHandlerHandle)
+	m.inner.RowChanged().Detach(m.rowChangedHandlerHandle)
+	m.inner.ColumnsReset().Detach(m.columnsResetHandlerHandle)
+}
+
+// Inner returns the wrapped TableModel.
+func (m *RowNumberTableModel) Inner() TableModel {
+	return m.inner
+}
+
+func (m *RowNumberTableModel) Columns() []TableColumn {
+	columns := []TableColumn{{Title: "#", DataType: DataTypeInt}}
+
+	return append(columns, m.inner.Columns()...)
+}
+
+func (m *RowNumberTableModel) RowCount() int {
+	return m.inner.RowCount()
+}
+
+func (m *RowNumberTableModel) Value(row, col int) interface{} {
+	if col == 0 {
+		return row + 1
+	}
+
+	return m.inner.Value(row, col-1)
+}
+
+// SortableRowNumberTableModel is a RowNumberTableModel that wraps a TableModel
+// implementing Sorter and forwards sorting to it. The row number column itself
+// is not sortable.
+type SortableRowNumberTableModel struct {
+	*RowNumberTableModel
+	sorter                   Sorter
+	sortChangedPublisher     EventPublisher
+	sortChangedHandlerHandle int
+}
+
+// NewSortableRowNumberTableModel returns a new *SortableRowNumberTableModel
+// that wraps inner, which must implement Sorter.
+func NewSortableRowNumberTableModel(inner TableModel) (*SortableRowNumberTableModel, error) {
+	sorter, ok := inner.(Sorter)
+	if !ok {
+		return nil, newError("inner model does not implement Sorter")
+	}
+
+	m := &SortableRowNumberTableModel{
+		RowNumberTableModel: NewRowNumberTableModel(inner),
+		sorter:              sorter,
+	}
+
+	m.sortChangedHandlerHandle = sorter.SortChanged().Attach(func() {
+		m.sortChangedPublisher.Publish()
+	})
+
+	return m, nil
+}
+
+// Dispose detaches the *SortableRowNumberTableModel from the events of the
+// inner model.
+func (m *SortableRowNumberTableModel) Dispose() {
+	m.sorter.SortChanged().Detach(m.sortChangedHandlerHandle)
+
+	m.RowNumberTableModel.Dispose()
+}
+
+func (m *SortableRowNumberTableModel) ColumnSortable(col int) bool {
+	if col == 0 {
+		return false
+	}
+
+	return m.sorter.ColumnSortable(col - 1)
+}
+
+func (m *SortableRowNumberTableModel) Sort(col int, order SortOrder) error {
+	if col == 0 {
+		return newError("row number column is not sortable")
+	}
+
+	if col == -1 {
+		return m.sorter.Sort(-1, order)
+	}
+
+	return m.sorter.Sort(col-1, order)
+}
+
+func (m *SortableRowNumberTableModel) SortChanged() *Event {
+	return m.sortChangedPublisher.Event()
+}
+
+func (m *SortableRowNumberTableModel) SortedColumn() int {
+	if col := m.sorter.SortedColumn(); col >= 0 {
+		return col + 1
+	}
+
+	return -1
+}
+
+func (m *SortableRowNumberTableModel) SortOrder() SortOrder {
+	return m.sorter.SortOrder()
+}
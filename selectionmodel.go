@@ -0,0 +1,83 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"sort"
+)
+
+// SelectionModel holds a set of selected item indexes, independent of any
+// widget.
+//
+// Widgets like TableView can be bound to a SelectionModel, so that multiple
+// views share their selection and the selection can be driven from code.
+type SelectionModel struct {
+	indexes          []int
+	changedPublisher EventPublisher
+}
+
+// NewSelectionModel returns a new, empty *SelectionModel.
+func NewSelectionModel() *SelectionModel {
+	return new(SelectionModel)
+}
+
+// SelectedIndexes returns the sorted indexes of the selected items.
+func (sm *SelectionModel) SelectedIndexes() []int {
+	indexes := make([]int, len(sm.indexes))
+	copy(indexes, sm.indexes)
+
+	return indexes
+}
+
+// SetSelectedIndexes sets the indexes of the selected items and publishes the
+// SelectionChanged event, if the selection changed.
+func (sm *SelectionModel) SetSelectedIndexes(indexes []int) {
+	sorted := make([]int, 0, len(indexes))
+	for _, index := range indexes {
+		if index > -1 {
+			sorted = append(sorted, index)
+		}
+	}
+	sort.Ints(sorted)
+
+	// Remove duplicates.
+	unique := sorted[:0]
+	for i, index := range sorted {
+		if i == 0 || index != sorted[i-1] {
+			unique = append(unique, index)
+		}
+	}
+
+	if len(unique) == len(sm.indexes) {
+		equal := true
+		for i := range unique {
+			if unique[i] != sm.indexes[i] {
+				equal = false
+				break
+			}
+		}
+
+		if equal {
+			return
+		}
+	}
+
+	sm.indexes = unique
+
+	sm.changedPublisher.Publish()
+}
+
+// IsSelected returns if the item at index index is selected.
+func (sm *SelectionModel) IsSelected(index int) bool {
+	i := sort.SearchInts(sm.indexes, index)
+
+	return i < len(sm.indexes) && sm.indexes[i] == index
+}
+
+// SelectionChanged returns the event that is published after the selection
+// changed.
+func (sm *SelectionModel) SelectionChanged() *Event {
+	return sm.changedPublisher.Event()
+}
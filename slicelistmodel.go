@@ -0,0 +1,62 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+// SliceListModel is a ListModel over a slice of values, that implements
+// ReorderableModel.
+type SliceListModel struct {
+	ListModelBase
+	items []interface{}
+}
+
+// NewSliceListModel returns a new *SliceListModel that presents items.
+func NewSliceListModel(items []interface{}) *SliceListModel {
+	return &SliceListModel{items: items}
+}
+
+// Items returns the items of the *SliceListModel.
+func (m *SliceListModel) Items() []interface{} {
+	return m.items
+}
+
+// SetItems sets the items of the *SliceListModel and publishes the ItemsReset
+// event.
+func (m *SliceListModel) SetItems(items []interface{}) {
+	m.items = items
+
+	m.PublishItemsReset()
+}
+
+func (m *SliceListModel) ItemCount() int {
+	return len(m.items)
+}
+
+func (m *SliceListModel) Value(index int) interface{} {
+	return m.items[index]
+}
+
+// MoveItem moves the item at index from to index to and publishes the
+// ItemsReset event.
+func (m *SliceListModel) MoveItem(from, to int) error {
+	if from < 0 || from >= len(m.items) || to < 0 || to >= len(m.items) {
+		return newError("index out of range")
+	}
+
+	if from == to {
+		return nil
+	}
+
+	item := m.items[from]
+	if from < to {
+		copy(m.items[from:to], m.items[from+1:to+1])
+	} else {
+		copy(m.items[to+1:from+1], m.items[to:from])
+	}
+	m.items[to] = item
+
+	m.PublishItemsReset()
+
+	return nil
+}
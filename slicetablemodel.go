@@ -0,0 +1,243 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"sort"
+)
+
+// SliceTableModel is a mutable, in-memory TableModel that stores its rows as
+// slices of values.
+//
+// It embeds a ColumnSorter, so it can be sorted by any column that has a
+// LessFunc or naturally ordered values.
+type SliceTableModel struct {
+	TableModelBase
+	ColumnSorter
+	columns []TableColumn
+	rows    [][]interface{}
+}
+
+// NewSliceTableModel returns a new, empty *SliceTableModel with the specified
+// columns.
+func NewSliceTableModel(columns []TableColumn) *SliceTableModel {
+	m := &SliceTableModel{columns: columns}
+
+	m.SetModel(m)
+
+	return m
+}
+
+func (m *SliceTableModel) Columns() []TableColumn {
+	return m.columns
+}
+
+func (m *SliceTableModel) RowCount() int {
+	return len(m.rows)
+}
+
+func (m *SliceTableModel) Value(row, col int) interface{} {
+	return m.rows[row][col]
+}
+
+// Row returns the values of the row at index i.
+func (m *SliceTableModel) Row(i int) []interface{} {
+	return m.rows[i]
+}
+
+// AppendRow appends a row with the specified values and publishes the
+// RowsReset event.
+//
+// The row is not sorted into place, call Sort again if necessary.
+func (m *SliceTableModel) AppendRow(values []interface{}) error {
+	if err := m.checkValues(values); err != nil {
+		return err
+	}
+
+	m.rows = append(m.rows, values)
+
+	m.PublishRowsReset()
+
+	return nil
+}
+
+// SetRow replaces the values of the row at index i and publishes the
+// RowChanged event.
+func (m *SliceTableModel) SetRow(i int, values []interface{}) error {
+	if err := m.checkIndex(i); err != nil {
+		return err
+	}
+	if err := m.checkValues(values); err != nil {
+		return err
+	}
+
+	m.rows[i] = values
+
+	m.PublishRowChanged(i)
+
+	return nil
+}
+
+// RemoveRow removes the row at index i and publishes the RowsReset event.
+func (m *SliceTableModel) RemoveRow(i int) error {
+	if err := m.checkIndex(i); err != nil {
+		return err
+	}
+
+	copy(m.rows[i:], m.rows[i+1:])
+	m.rows[len(m.rows)-1] = nil
+	m.rows = m.rows[:len(m.rows)-1]
+
+	m.PublishRowsReset()
+
+	return nil
+}
+
+// Clear removes all rows and publishes the RowsReset event.
+func (m *SliceTableModel) Clear() {
+	m.rows = nil
+
+	m.PublishRowsReset()
+}
+
+// Swap swaps the rows at indexes i and j, so the ColumnSorter can sort them.
+func (m *SliceTableModel) Swap(i, j int) {
+	m.rows[i], m.rows[j] = m.rows[j], m.rows[i]
+}
+
+func (m *SliceTableModel) checkIndex(i int) error {
+	if i < 0 || i >= len(m.rows) {
+		return newError("index out of range")
+	}
+
+	return nil
+}
+
+func (m *SliceTableModel) checkValues(values []interface{}) error {
+	if len(values) != len(m.columns) {
+		return newError("number of values must match number of columns")
+	}
+
+	return nil
+}
+
+// Update returns a *SliceTableModelUpdater, that buffers several changes to the
+// *SliceTableModel and applies them at once when it is closed.
+//
+// This way, an attached widget like TableView is only reset once.
+func (m *SliceTableModel) Update() *SliceTableModelUpdater {
+	rows := make([][]interface{}, len(m.rows))
+	copy(rows, m.rows)
+
+	return &SliceTableModelUpdater{model: m, rows: rows, changed: make(map[int]bool)}
+}
+
+// SliceTableModelUpdater buffers changes to a *SliceTableModel, see
+// SliceTableModel.Update.
+//
+// Row indexes refer to the rows as they are after the changes buffered so far.
+type SliceTableModelUpdater struct {
+	model   *SliceTableModel
+	rows    [][]interface{}
+	changed map[int]bool
+	reset   bool
+	closed  bool
+}
+
+// AppendRow buffers appending a row with the specified values.
+func (u *SliceTableModelUpdater) AppendRow(values []interface{}) error {
+	if err := u.check(values); err != nil {
+		return err
+	}
+
+	u.rows = append(u.rows, values)
+	u.reset = true
+
+	return nil
+}
+
+// SetRow buffers replacing the values of the row at index i.
+func (u *SliceTableModelUpdater) SetRow(i int, values []interface{}) error {
+	if err := u.check(values); err != nil {
+		return err
+	}
+	if i < 0 || i >= len(u.rows) {
+		return newError("index out of range")
+	}
+
+	u.rows[i] = values
+	u.changed[i] = true
+
+	return nil
+}
+
+// RemoveRow buffers removing the row at index i.
+func (u *SliceTableModelUpdater) RemoveRow(i int) error {
+	if err := u.check(nil); err != nil {
+		return err
+	}
+	if i < 0 || i >= len(u.rows) {
+		return newError("index out of range")
+	}
+
+	u.rows = append(u.rows[:i], u.rows[i+1:]...)
+	u.reset = true
+
+	return nil
+}
+
+// Clear buffers removing all rows.
+func (u *SliceTableModelUpdater) Clear() error {
+	if err := u.check(nil); err != nil {
+		return err
+	}
+
+	u.rows = nil
+	u.reset = true
+
+	return nil
+}
+
+// Close applies the buffered changes to the *SliceTableModel.
+//
+// It publishes a single RowsReset event if rows were added or removed, or the
+// RowChanged event for each changed row otherwise.
+func (u *SliceTableModelUpdater) Close() error {
+	if u.closed {
+		return newError("updater already closed")
+	}
+	u.closed = true
+
+	u.model.rows = u.rows
+
+	if u.reset {
+		u.model.PublishRowsReset()
+		return nil
+	}
+
+	rows := make([]int, 0, len(u.changed))
+	for row := range u.changed {
+		rows = append(rows, row)
+	}
+	sort.Ints(rows)
+
+	for _, row := range rows {
+		u.model.PublishRowChanged(row)
+	}
+
+	return nil
+}
+
+func (u *SliceTableModelUpdater) check(values []interface{}) error {
+	if u.closed {
+		return newError("updater already closed")
+	}
+
+	if values != nil {
+		return u.model.checkValues(values)
+	}
+
+	return nil
+}
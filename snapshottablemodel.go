@@ -0,0 +1,52 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+// snapshotTableModel is an immutable TableModel, see SnapshotTableModel.
+type snapshotTableModel struct {
+	TableModelBase
+	columns []TableColumn
+	rows    [][]interface{}
+}
+
+// SnapshotTableModel returns a TableModel that holds a copy of the columns and
+// values of model, e.g. to export them from another goroutine while model keeps
+// changing.
+//
+// The snapshot is a point-in-time copy, that does not follow later changes of
+// model and never publishes any events. Since it is never modified, it is safe
+// to read from any goroutine. Call SnapshotTableModel from the goroutine that
+// modifies model, usually the one that runs the message loop.
+//
+// Only the values themselves are copied, so values that are pointers or
+// reference types still share the data they refer to with model.
+func SnapshotTableModel(model TableModel) TableModel {
+	columns := make([]TableColumn, len(model.Columns()))
+	copy(columns, model.Columns())
+
+	rows := make([][]interface{}, model.RowCount())
+	for row := range rows {
+		values := make([]interface{}, len(columns))
+		for col := range values {
+			values[col] = model.Value(row, col)
+		}
+
+		rows[row] = values
+	}
+
+	return &snapshotTableModel{columns: columns, rows: rows}
+}
+
+func (m *snapshotTableModel) Columns() []TableColumn {
+	return m.columns
+}
+
+func (m *snapshotTableModel) RowCount() int {
+	return len(m.rows)
+}
+
+func (m *snapshotTableModel) Value(row, col int) interface{} {
+	return m.rows[row][col]
+}
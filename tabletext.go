@@ -0,0 +1,299 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatCellText returns the text that a TableView displays for value in a
+// cell of column.
+func formatCellText(value interface{}, column *TableColumn) string {
+	prec := column.Precision
+	if prec == 0 {
+		prec = 2
+	}
+
+	// The defaults of a DataType only apply to values of matching types,
+	// anything else is formatted using %v.
+	format := column.Format
+	if format == "" {
+		format = "%v"
+
+		switch column.DataType {
+		case DataTypeInt:
+			if isIntegerValue(value) {
+				format = "%d"
+			}
+
+		case DataTypeDate:
+			if t, ok := value.(time.Time); ok {
+				return t.Format("2006-01-02")
+			}
+
+		case DataTypeCurrency:
+			if text, ok := formatCurrencyValue(value, prec); ok {
+				return text
+			}
+		}
+	}
+
+	switch val := value.(type) {
+	case string:
+		return val
+
+	case float32:
+		text, _ := formatFloat(float64(val), prec)
+		return text
+
+	case float64:
+		text, _ := formatFloat(val, prec)
+		return text
+
+	case time.Time:
+		return val.Format(column.Format)
+
+	case *big.Rat:
+		text, _ := formatRat(val, prec)
+		return text
+	}
+
+	return fmt.Sprintf(format, value)
+}
+
+// isIntegerValue returns if value is of one of the integer types.
+func isIntegerValue(value interface{}) bool {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	}
+
+	return false
+}
+
+// formatCurrencyValue formats numeric values as currency.
+func formatCurrencyValue(value interface{}, prec int) (string, bool) {
+	var s string
+
+	switch val := value.(type) {
+	case float32:
+		s = strconv.FormatFloat(float64(val), 'f', prec, 32)
+
+	case float64:
+		s = strconv.FormatFloat(val, 'f', prec, 64)
+
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s = fmt.Sprintf("%d", val)
+
+	case *big.Rat:
+		s = val.FloatString(prec)
+
+	default:
+		return "", false
+	}
+
+	text, err := formatCurrencyString(s)
+	if err != nil {
+		return "", false
+	}
+
+	return text, true
+}
+
+// defaultAlignment returns the alignment of column, considering its DataType.
+func defaultAlignment(column *TableColumn) Alignment1D {
+	if column.Alignment != AlignNear {
+		return column.Alignment
+	}
+
+	switch column.DataType {
+	case DataTypeInt, DataTypeFloat, DataTypeCurrency:
+		return AlignFar
+
+	case DataTypeBool:
+		return AlignCenter
+	}
+
+	return AlignNear
+}
+
+// TableModelToTSV returns the specified rows of model as tab separated values,
+// e.g. for copying them to the clipboard.
+//
+// The first line contains the column titles. Values are formatted the same way
+// a TableView displays them, nil values as empty cells. Tabs and line breaks
+// inside values are replaced with spaces.
+func TableModelToTSV(model TableModel, rows []int) string {
+	buf := bytes.NewBuffer(nil)
+
+	columns := model.Columns()
+
+	writeLine := func(cell func(col int) string) {
+		for col := range columns {
+			if col > 0 {
+				buf.WriteString("\t")
+			}
+
+			buf.WriteString(tsvReplacer.Replace(cell(col)))
+		}
+
+		buf.WriteString("\r\n")
+	}
+
+	writeLine(func(col int) string {
+		return columns[col].Title
+	})
+
+	for _, row := range rows {
+		writeLine(func(col int) string {
+			value := model.Value(row, col)
+			if value == nil {
+				return ""
+			}
+
+			return formatCellText(value, &columns[col])
+		})
+	}
+
+	return buf.String()
+}
+
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// ParseTSV splits tab separated values, e.g. pasted from the clipboard, into
+// lines of cells.
+//
+// Cells may be quoted the way Excel copies them: a cell that starts with a
+// quote extends to the matching closing quote and may contain tabs and line
+// breaks, with a doubled quote standing for a literal one. A trailing line
+// break does not result in an additional empty line.
+func ParseTSV(text string) [][]string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	if strings.HasSuffix(text, "\n") {
+		text = text[:len(text)-1]
+	}
+
+	if text == "" {
+		return nil
+	}
+
+	var lines [][]string
+	var line []string
+	var cell bytes.Buffer
+	quoted := false
+	cellStart := true
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch {
+		case quoted:
+			if c != '"' {
+				cell.WriteByte(c)
+			} else if i+1 < len(text) && text[i+1] == '"' {
+				cell.WriteByte('"')
+				i++
+			} else {
+				quoted = false
+			}
+
+		case c == '"' && cellStart:
+			quoted = true
+
+		case c == '\t' || c == '\n':
+			line = append(line, cell.String())
+			cell.Reset()
+
+			if c == '\n' {
+				lines = append(lines, line)
+				line = nil
+			}
+
+			cellStart = true
+			continue
+
+		default:
+			cell.WriteByte(c)
+		}
+
+		cellStart = false
+	}
+
+	return append(lines, append(line, cell.String()))
+}
+
+// CSVOptions controls how ExportTableModelCSV writes a TableModel.
+type CSVOptions struct {
+	// Delimiter separates the values of a row. If it is 0, a comma is used.
+	Delimiter rune
+
+	// Headers specifies if the first line should contain the column titles.
+	Headers bool
+
+	// Format specifies if values should be formatted the same way a TableView
+	// displays them. Otherwise they are written as formatted by fmt.Sprint.
+	// Either way, nil values are written as empty fields.
+	Format bool
+}
+
+// ExportTableModelCSV writes all rows of model to w as comma separated values.
+//
+// Values containing the delimiter, quotes or line breaks are quoted.
+func ExportTableModelCSV(w io.Writer, model TableModel, opts CSVOptions) error {
+	cw := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		cw.Comma = opts.Delimiter
+	}
+	cw.UseCRLF = true
+
+	columns := model.Columns()
+	record := make([]string, len(columns))
+
+	if opts.Headers {
+		for col := range columns {
+			record[col] = columns[col].Title
+		}
+
+		if err := cw.Write(record); err != nil {
+			return wrapError(err)
+		}
+	}
+
+	for row, count := 0, model.RowCount(); row < count; row++ {
+		for col := range columns {
+			value := model.Value(row, col)
+
+			switch {
+			case value == nil:
+				record[col] = ""
+
+			case opts.Format:
+				record[col] = formatCellText(value, &columns[col])
+
+			default:
+				record[col] = fmt.Sprint(value)
+			}
+		}
+
+		if err := cw.Write(record); err != nil {
+			return wrapError(err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
@@ -8,11 +8,9 @@ import (
 	"bytes"
 	"fmt"
 	"log"
-	"math/big"
 	"strconv"
 	"strings"
 	"syscall"
-	"time"
 	"unsafe"
 )
 
@@ -36,11 +34,24 @@ type TableView struct {
 	imageProvider                   ImageProvider
 	hasAppliedImageList             bool
 	imageList                       *ImageList
+	headerImageList                 *ImageList
+	rowHeightProvider               RowHeightProvider
+	rowSelectableProvider           RowSelectableProvider
+	rowHeightImageList              *ImageList
+	rowHeight                       int
+	accessibleValueProvider         AccessibleValueProvider
+	accessibleRows                  map[int]bool
+	cellSpanProvider                CellSpanProvider
 	imageUintptr2Index              map[uintptr]int32
 	filePath2IconIndex              map[string]int32
 	rowsResetHandlerHandle          int
 	rowChangedHandlerHandle         int
+	columnsResetHandlerHandle       int
+	selectionModel                  *SelectionModel
+	selectionChangedHandlerHandle   int
+	applyingSelection               bool
 	sortChangedHandlerHandle        int
+	loadingChangedHandlerHandle     int
 	columns                         []TableColumn
 	currentIndex                    int
 	currentIndexChangedPublisher    EventPublisher
@@ -103,6 +114,17 @@ func NewTableView(parent Container) (*TableView, error) {
 func (tv *TableView) Dispose() {
 	tv.detachModel()
 
+	tv.clearAccessibleValues()
+
+	tv.SetSelectionModel(nil)
+
+	if tv.rowHeightImageList != nil {
+		tv.rowHeightImageList.Dispose()
+		tv.rowHeightImageList = nil
+	}
+
+	tv.disposeHeaderImageList()
+
 	if tv.hWnd != 0 {
 		if !KillTimer(tv.hWnd, tableViewCurrentIndexChangedTimerId) {
 			lastError("KillTimer")
@@ -163,17 +185,160 @@ func (tv *TableView) SetAlternatingRowBGColor(c Color) {
 	tv.Invalidate()
 }
 
+func (tv *TableView) rowBGColor(row int) Color {
+	if row%2 == 1 {
+		return tv.alternatingRowBGColor
+	}
+
+	return defaultTVRowBGColor
+}
+
+// maxCellRowSpan is how many rows up TableView looks for a span that covers a
+// cell, see CellSpanProvider.
+const maxCellRowSpan = 16
+
+// cellSpan returns the cell whose span covers the cell at row and col, which
+// may be the cell itself, and the normalized span.
+func (tv *TableView) cellSpan(row, col int) (spanRow, spanCol, rowSpan, colSpan int, ok bool) {
+	for r := maxi(0, row-maxCellRowSpan+1); r <= row; r++ {
+		for c := 0; c <= col; c++ {
+			rs, cs := tv.cellSpanProvider.CellSpan(r, c)
+			rs, cs = mini(maxi(rs, 1), maxCellRowSpan), maxi(cs, 1)
+
+			if rs == 1 && cs == 1 {
+				continue
+			}
+
+			if r+rs > row && c+cs > col {
+				return r, c, rs, cs, true
+			}
+		}
+	}
+
+	return 0, 0, 1, 1, false
+}
+
+func (tv *TableView) subItemRect(row, col int) Rectangle {
+	rc := RECT{Top: int32(col), Left: LVIR_BOUNDS}
+	if col == 0 {
+		// For the first column, LVIR_BOUNDS returns the bounds of the whole row.
+		rc.Left = LVIR_LABEL
+	}
+
+	tv.SendMessage(LVM_GETSUBITEMRECT, uintptr(row), uintptr(unsafe.Pointer(&rc)))
+
+	return rectangleFromRECT(rc)
+}
+
+// drawSpannedCell draws the part of a merged cell that falls into the cell at
+// row and col and returns true, or returns false if the cell is not merged.
+//
+// Every covered cell draws the content of the whole merged cell clipped to its
+// own bounds, so partial repaints look right.
+func (tv *TableView) drawSpannedCell(hdc HDC, row, col int) bool {
+	if tv.cellSpanProvider == nil {
+		return false
+	}
+
+	spanRow, spanCol, rowSpan, colSpan, ok := tv.cellSpan(row, col)
+	if !ok {
+		return false
+	}
+
+	lastRow := mini(spanRow+rowSpan, tv.model.RowCount()) - 1
+	lastCol := mini(spanCol+colSpan, len(tv.columns)) - 1
+
+	first, last := tv.subItemRect(spanRow, spanCol), tv.subItemRect(lastRow, lastCol)
+	bounds := Rectangle{first.X, first.Y, last.X + last.Width - first.X, last.Y + last.Height - first.Y}
+
+	cell := tv.subItemRect(row, col)
+
+	canvas, err := newCanvasFromHDC(hdc)
+	if err != nil {
+		return false
+	}
+	defer canvas.Dispose()
+
+	savedDC := SaveDC(hdc)
+	if savedDC == 0 {
+		return false
+	}
+	defer RestoreDC(hdc, savedDC)
+
+	IntersectClipRect(
+		hdc,
+		int32(cell.X),
+		int32(cell.Y),
+		int32(cell.X+cell.Width),
+		int32(cell.Y+cell.Height))
+
+	brush, err := tv.cachedSolidColorBrush(tv.rowBGColor(spanRow))
+	if err != nil {
+		return false
+	}
+	canvas.FillRectangle(brush, bounds)
+
+	column := &tv.columns[spanCol]
+
+	format := TextVCenter | TextSingleLine | TextEndEllipsis
+	switch defaultAlignment(column) {
+	case AlignCenter:
+		format |= TextCenter
+
+	case AlignFar:
+		format |= TextRight
+	}
+
+	// Leave a margin like the list view does.
+	bounds.X += 6
+	bounds.Width -= 12
+
+	canvas.DrawText(
+		formatCellText(tv.model.Value(spanRow, spanCol), column),
+		tv.Font(),
+		Color(GetSysColor(COLOR_WINDOWTEXT)),
+		bounds,
+		format)
+
+	return true
+}
+
 func (tv *TableView) attachModel() {
 	tv.rowsResetHandlerHandle = tv.model.RowsReset().Attach(func() {
+		tv.clearAccessibleValues()
+
 		tv.setItemCount()
 
+		tv.applyRowHeight()
+
+		tv.applyDefaultSort()
+
 		tv.SetCurrentIndex(-1)
 	})
 
+	tv.columnsResetHandlerHandle = tv.model.ColumnsReset().Attach(func() {
+		tv.SetSuspended(true)
+		defer tv.SetSuspended(false)
+
+		if err := tv.deleteColumns(); err != nil {
+			return
+		}
+
+		tv.insertColumns()
+
+		tv.clearAccessibleValues()
+
+		tv.Invalidate()
+	})
+
 	tv.rowChangedHandlerHandle = tv.model.RowChanged().Attach(func(row int) {
 		if FALSE == tv.SendMessage(LVM_UPDATE, uintptr(row), 0) {
 			newError("SendMessage(LVM_UPDATE)")
 		}
+
+		if tv.accessibleRows[row] {
+			tv.applyAccessibleValue(row)
+		}
 	})
 
 	if sorter, ok := tv.model.(Sorter); ok {
@@ -181,6 +346,13 @@ func (tv *TableView) attachModel() {
 			col := sorter.SortedColumn()
 			tv.setSelectedColumnIndex(col)
 			tv.setSortIcon(col, sorter.SortOrder())
+			tv.updateColumnTitles()
+			tv.Invalidate()
+		})
+	}
+
+	if lsp, ok := tv.model.(LoadingStateProvider); ok {
+		tv.loadingChangedHandlerHandle = lsp.LoadingChanged().Attach(func() {
 			tv.Invalidate()
 		})
 	}
@@ -189,9 +361,13 @@ func (tv *TableView) attachModel() {
 func (tv *TableView) detachModel() {
 	tv.model.RowsReset().Detach(tv.rowsResetHandlerHandle)
 	tv.model.RowChanged().Detach(tv.rowChangedHandlerHandle)
+	tv.model.ColumnsReset().Detach(tv.columnsResetHandlerHandle)
 	if sorter, ok := tv.model.(Sorter); ok {
 		sorter.SortChanged().Detach(tv.sortChangedHandlerHandle)
 	}
+	if lsp, ok := tv.model.(LoadingStateProvider); ok {
+		lsp.LoadingChanged().Detach(tv.loadingChangedHandlerHandle)
+	}
 }
 
 // Model returns the TableModel that provides data to the *TableView.
@@ -205,74 +381,385 @@ func (tv *TableView) SetModel(model TableModel) error {
 	defer tv.SetSuspended(false)
 
 	if tv.model != nil {
-		for _ = range tv.columns {
-			if FALSE == tv.SendMessage(LVM_DELETECOLUMN, 0, 0) {
-				return newError("SendMessage(LVM_DELETECOLUMN)")
-			}
+		if err := tv.deleteColumns(); err != nil {
+			return err
 		}
 
 		tv.detachModel()
 	}
 
+	tv.clearAccessibleValues()
+
 	tv.model = model
 
 	tv.itemChecker, _ = model.(ItemChecker)
 	tv.imageProvider, _ = model.(ImageProvider)
+	tv.rowHeightProvider, _ = model.(RowHeightProvider)
+	tv.rowSelectableProvider, _ = model.(RowSelectableProvider)
+	tv.accessibleValueProvider, _ = model.(AccessibleValueProvider)
+	tv.cellSpanProvider, _ = model.(CellSpanProvider)
 
 	if tv.imageList != nil {
 		tv.SendMessage(LVM_SETIMAGELIST, LVSIL_SMALL, 0)
 		tv.imageList.Dispose()
 		tv.imageList = nil
 	}
+	if tv.rowHeightImageList != nil {
+		tv.SendMessage(LVM_SETIMAGELIST, LVSIL_SMALL, 0)
+		tv.rowHeightImageList.Dispose()
+		tv.rowHeightImageList = nil
+		tv.rowHeight = 0
+	}
 	tv.hasAppliedImageList = false
 
 	if model != nil {
 		tv.attachModel()
 
-		tv.columns = model.Columns()
+		if err := tv.insertColumns(); err != nil {
+			return err
+		}
 
-		for i, column := range tv.columns {
-			if column.Format == "" {
-				tv.columns[i].Format = "%v"
-			}
+		if err := tv.setItemCount(); err != nil {
+			return err
+		}
 
-			var lvc LVCOLUMN
+		tv.applyRowHeight()
 
-			lvc.Mask = LVCF_FMT | LVCF_WIDTH | LVCF_TEXT | LVCF_SUBITEM
-			lvc.ISubItem = int32(i)
-			lvc.PszText = syscall.StringToUTF16Ptr(column.Title)
-			if column.Width > 0 {
-				lvc.Cx = int32(column.Width)
-			} else {
-				lvc.Cx = 100
-			}
+		tv.applyDefaultSort()
+	}
+
+	return nil
+}
 
-			switch column.Alignment {
-			case AlignCenter:
-				lvc.Fmt = 2
+// applyAccessibleValue annotates the MSAA name of row with the accessible
+// values of its cells. Rows are annotated lazily, the first time the list view
+// asks for their display info, so only rows that have been visible cost a call.
+func (tv *TableView) applyAccessibleValue(row int) error {
+	var values []string
+	for col := range tv.columns {
+		if value := tv.accessibleValueProvider.AccessibleValue(row, col); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	// MSAA child ids of list view items are the item index plus one.
+	if err := setAccessibleProp(tv.hWnd, uint32(row+1), propIDAccName, strings.Join(values, ", ")); err != nil {
+		return err
+	}
+
+	if tv.accessibleRows == nil {
+		tv.accessibleRows = make(map[int]bool)
+	}
+	tv.accessibleRows[row] = true
+
+	return nil
+}
+
+func (tv *TableView) clearAccessibleValues() {
+	for row := range tv.accessibleRows {
+		clearAccessibleProps(tv.hWnd, uint32(row+1))
+	}
+
+	tv.accessibleRows = nil
+}
+
+func (tv *TableView) deleteColumns() error {
+	for _ = range tv.columns {
+		if FALSE == tv.SendMessage(LVM_DELETECOLUMN, 0, 0) {
+			return newError("SendMessage(LVM_DELETECOLUMN)")
+		}
+	}
+
+	tv.columns = nil
+
+	return nil
+}
+
+func (tv *TableView) insertColumns() error {
+	tv.columns = tv.model.Columns()
+
+	for i, column := range tv.columns {
+		if column.Format == "" && column.DataType == DataTypeDefault {
+			tv.columns[i].Format = "%v"
+		}
+
+		var lvc LVCOLUMN
+
+		lvc.Mask = LVCF_FMT | LVCF_WIDTH | LVCF_TEXT | LVCF_SUBITEM
+		lvc.ISubItem = int32(i)
+		lvc.PszText = syscall.StringToUTF16Ptr(column.Title)
+		if column.Hidden {
+			lvc.Cx = 0
+		} else if column.Width > 0 {
+			lvc.Cx = int32(column.Width)
+		} else {
+			lvc.Cx = 100
+		}
+
+		switch defaultAlignment(&column) {
+		case AlignCenter:
+			lvc.Fmt = 2
+
+		case AlignFar:
+			lvc.Fmt = 1
+		}
+
+		j := tv.SendMessage(LVM_INSERTCOLUMN, uintptr(i), uintptr(unsafe.Pointer(&lvc)))
+		if int(j) == -1 {
+			return newError("TableView.SetModel: Failed to insert column.")
+		}
+	}
+
+	if sorter, ok := tv.model.(Sorter); ok {
+		col := sorter.SortedColumn()
+		tv.setSelectedColumnIndex(col)
+		tv.setSortIcon(col, sorter.SortOrder())
+	}
+
+	if err := tv.applyColumnImages(); err != nil {
+		return err
+	}
+
+	return tv.updateColumnTitles()
+}
+
+// applyColumnImages displays the images of a model that implements
+// ColumnImageProvider in the column headers.
+func (tv *TableView) applyColumnImages() error {
+	tv.disposeHeaderImageList()
+
+	cip, ok := tv.model.(ColumnImageProvider)
+	if !ok {
+		return nil
+	}
+
+	headerHwnd := HWND(tv.SendMessage(LVM_GETHEADER, 0, 0))
+
+	for i := range tv.columns {
+		image := cip.ColumnImage(i)
+		if image == nil {
+			continue
+		}
 
-			case AlignFar:
-				lvc.Fmt = 1
+		if tv.headerImageList == nil {
+			imgSize := Size{
+				int(GetSystemMetrics(SM_CXSMICON)),
+				int(GetSystemMetrics(SM_CYSMICON)),
 			}
 
-			j := tv.SendMessage(LVM_INSERTCOLUMN, uintptr(i), uintptr(unsafe.Pointer(&lvc)))
-			if int(j) == -1 {
-				return newError("TableView.SetModel: Failed to insert column.")
+			var err error
+			if tv.headerImageList, err = NewImageList(imgSize, 0); err != nil {
+				return err
 			}
+
+			SendMessage(headerHwnd, HDM_SETIMAGELIST, 0, uintptr(tv.headerImageList.hIml))
 		}
 
-		if sorter, ok := tv.model.(Sorter); ok {
-			col := sorter.SortedColumn()
-			tv.setSelectedColumnIndex(col)
-			tv.setSortIcon(col, sorter.SortOrder())
+		imageIndex := tv.headerImageIndex(image)
+		if imageIndex < 0 {
+			continue
 		}
 
-		return tv.setItemCount()
+		item := HDITEM{Mask: HDI_FORMAT}
+		if SendMessage(headerHwnd, HDM_GETITEM, uintptr(i), uintptr(unsafe.Pointer(&item))) == 0 {
+			return newError("SendMessage(HDM_GETITEM)")
+		}
+
+		item.Mask = HDI_FORMAT | HDI_IMAGE
+		item.Fmt |= HDF_IMAGE
+		item.IImage = imageIndex
+
+		if SendMessage(headerHwnd, HDM_SETITEM, uintptr(i), uintptr(unsafe.Pointer(&item))) == 0 {
+			return newError("SendMessage(HDM_SETITEM)")
+		}
 	}
 
 	return nil
 }
 
+// headerImageIndex adds image to the image list of the header and returns its
+// index, or -1 if that failed.
+func (tv *TableView) headerImageIndex(image interface{}) int32 {
+	switch img := image.(type) {
+	case *Bitmap:
+		return ImageList_AddMasked(tv.headerImageList.hIml, img.hBmp, 0)
+
+	case *Icon:
+		return ImageList_ReplaceIcon(tv.headerImageList.hIml, -1, img.hIcon)
+
+	case string:
+		var shfi SHFILEINFO
+		if 0 == SHGetFileInfo(
+			syscall.StringToUTF16Ptr(img),
+			0,
+			&shfi,
+			uint32(unsafe.Sizeof(shfi)),
+			SHGFI_ICON|SHGFI_SMALLICON) {
+
+			return -1
+		}
+		defer DestroyIcon(shfi.HIcon)
+
+		return ImageList_ReplaceIcon(tv.headerImageList.hIml, -1, shfi.HIcon)
+	}
+
+	return -1
+}
+
+func (tv *TableView) disposeHeaderImageList() {
+	if tv.headerImageList == nil {
+		return
+	}
+
+	if headerHwnd := HWND(tv.SendMessage(LVM_GETHEADER, 0, 0)); headerHwnd != 0 {
+		SendMessage(headerHwnd, HDM_SETIMAGELIST, 0, 0)
+	}
+
+	tv.headerImageList.Dispose()
+	tv.headerImageList = nil
+}
+
+// NonReorderableColumnCount returns the number of leading columns that can not
+// be reordered, as declared by a model that implements
+// NonReorderableColumnsProvider.
+//
+// These columns keep their position when the user drags column headers around
+// and other columns can not be moved in between them. They still scroll
+// horizontally with the other columns.
+func (tv *TableView) NonReorderableColumnCount() int {
+	nrcp, ok := tv.model.(NonReorderableColumnsProvider)
+	if !ok {
+		return 0
+	}
+
+	return mini(nrcp.NonReorderableColumnCount(), len(tv.columns))
+}
+
+// enforceColumnOrder moves the non-reorderable columns back to the front, if
+// the user reordered columns.
+func (tv *TableView) enforceColumnOrder() {
+	fixed := tv.NonReorderableColumnCount()
+	count := len(tv.columns)
+	if fixed == 0 || count == 0 {
+		return
+	}
+
+	indices := make([]int32, count)
+	tv.SendMessage(LVM_GETCOLUMNORDERARRAY, uintptr(count), uintptr(unsafe.Pointer(&indices[0])))
+
+	order := make([]int32, 0, count)
+	for i := 0; i < fixed; i++ {
+		order = append(order, int32(i))
+	}
+	for _, idx := range indices {
+		if int(idx) >= fixed {
+			order = append(order, idx)
+		}
+	}
+
+	for i := range order {
+		if order[i] != indices[i] {
+			tv.SendMessage(LVM_SETCOLUMNORDERARRAY, uintptr(count), uintptr(unsafe.Pointer(&order[0])))
+			tv.Invalidate()
+			return
+		}
+	}
+}
+
+// itemsBounds returns the client area of the *TableView below the header.
+func (tv *TableView) itemsBounds() Rectangle {
+	bounds := tv.ClientBounds()
+
+	if hwndHeader := HWND(tv.SendMessage(LVM_GETHEADER, 0, 0)); hwndHeader != 0 && IsWindowVisible(hwndHeader) {
+		var r RECT
+		if GetWindowRect(hwndHeader, &r) {
+			height := int(r.Bottom - r.Top)
+			bounds.Y += height
+			bounds.Height -= height
+		}
+	}
+
+	return bounds
+}
+
+// drawLoadingOverlay covers the items with a loading message, while the model
+// is loading.
+func (tv *TableView) drawLoadingOverlay() {
+	canvas, err := newCanvasFromHWND(tv.hWnd)
+	if err != nil {
+		return
+	}
+	defer canvas.Dispose()
+
+	brush, err := tv.cachedSolidColorBrush(Color(GetSysColor(COLOR_WINDOW)))
+	if err != nil {
+		return
+	}
+
+	bounds := tv.itemsBounds()
+
+	canvas.FillRectangle(brush, bounds)
+
+	tv.drawEmptyText("Loading...", bounds)
+}
+
+// applyDefaultSort sorts the model by its default sort, once it has rows.
+func (tv *TableView) applyDefaultSort() {
+	sorter, ok := tv.model.(Sorter)
+	if !ok {
+		return
+	}
+
+	ds, ok := tv.model.(defaultSorter)
+	if !ok || tv.model.RowCount() == 0 {
+		return
+	}
+
+	if col, order, ok := ds.takeDefaultSort(); ok {
+		sorter.Sort(col, order)
+	}
+}
+
+// applyRowHeight applies the row height requested by a RowHeightProvider model.
+//
+// A list view control only supports a uniform row height, so the tallest row
+// determines the height of all rows. The height is applied through the small
+// image list, so it is not supported together with an ImageProvider.
+func (tv *TableView) applyRowHeight() {
+	if tv.rowHeightProvider == nil || tv.imageProvider != nil {
+		return
+	}
+
+	var height int
+	for row, count := 0, tv.model.RowCount(); row < count; row++ {
+		height = maxi(height, tv.rowHeightProvider.RowHeight(row))
+	}
+
+	if tv.rowHeightImageList != nil {
+		if height == tv.rowHeight {
+			return
+		}
+
+		tv.SendMessage(LVM_SETIMAGELIST, LVSIL_SMALL, 0)
+		tv.rowHeightImageList.Dispose()
+		tv.rowHeightImageList = nil
+		tv.rowHeight = 0
+	}
+
+	if height == 0 {
+		return
+	}
+
+	var err error
+	if tv.rowHeightImageList, err = NewImageList(Size{1, height}, 0); err != nil {
+		return
+	}
+
+	tv.rowHeight = height
+
+	tv.SendMessage(LVM_SETIMAGELIST, LVSIL_SMALL, uintptr(tv.rowHeightImageList.hIml))
+}
+
 func (tv *TableView) setItemCount() error {
 	var count int
 
@@ -326,6 +813,33 @@ func (tv *TableView) setSelectedColumnIndex(value int) {
 	tv.SendMessage(LVM_SETSELECTEDCOLUMN, uintptr(value), 0)
 }
 
+// updateColumnTitles updates the header text of columns that have a TitleFunc,
+// according to the sort state of the model.
+func (tv *TableView) updateColumnTitles() error {
+	sortedCol, order := -1, SortAscending
+	if sorter, ok := tv.model.(Sorter); ok {
+		sortedCol, order = sorter.SortedColumn(), sorter.SortOrder()
+	}
+
+	for i, column := range tv.columns {
+		if column.TitleFunc == nil {
+			continue
+		}
+
+		title := column.TitleFunc(column.Title, i == sortedCol, order)
+
+		var lvc LVCOLUMN
+		lvc.Mask = LVCF_TEXT
+		lvc.PszText = syscall.StringToUTF16Ptr(title)
+
+		if FALSE == tv.SendMessage(LVM_SETCOLUMN, uintptr(i), uintptr(unsafe.Pointer(&lvc))) {
+			return newError("SendMessage(LVM_SETCOLUMN)")
+		}
+	}
+
+	return nil
+}
+
 func (tv *TableView) setSortIcon(index int, order SortOrder) error {
 	headerHwnd := HWND(tv.SendMessage(LVM_GETHEADER, 0, 0))
 
@@ -390,6 +904,10 @@ func (tv *TableView) CurrentIndex() int {
 //
 // Call this with a value of -1 to have no current item.
 func (tv *TableView) SetCurrentIndex(value int) error {
+	if value > -1 && !tv.rowSelectable(value) {
+		return newError("row is not selectable")
+	}
+
 	var lvi LVITEM
 
 	lvi.StateMask = LVIS_FOCUSED | LVIS_SELECTED
@@ -445,6 +963,104 @@ func (tv *TableView) SelectedIndexes() *IndexList {
 	return tv.selectedIndexes
 }
 
+// SelectionModel returns the *SelectionModel the *TableView is bound to, or nil.
+func (tv *TableView) SelectionModel() *SelectionModel {
+	return tv.selectionModel
+}
+
+// SetSelectionModel binds the *TableView to a *SelectionModel.
+//
+// The selection of the *TableView is replaced by the selection of the model
+// and both are kept in sync afterwards. Pass nil to unbind.
+func (tv *TableView) SetSelectionModel(sm *SelectionModel) {
+	if tv.selectionModel != nil {
+		tv.selectionModel.SelectionChanged().Detach(tv.selectionChangedHandlerHandle)
+	}
+
+	tv.selectionModel = sm
+
+	if sm != nil {
+		tv.selectionChangedHandlerHandle = sm.SelectionChanged().Attach(func() {
+			tv.applySelectionModel()
+		})
+
+		tv.applySelectionModel()
+	}
+}
+
+// applySelectionModel selects the items selected in the *SelectionModel.
+func (tv *TableView) applySelectionModel() {
+	tv.applyingSelection = true
+	defer func() {
+		tv.applyingSelection = false
+	}()
+
+	var lvi LVITEM
+	lvi.StateMask = LVIS_SELECTED
+
+	// Index -1 deselects all items.
+	tv.SendMessage(LVM_SETITEMSTATE, ^uintptr(0), uintptr(unsafe.Pointer(&lvi)))
+
+	lvi.State = LVIS_SELECTED
+	for _, index := range tv.selectionModel.SelectedIndexes() {
+		if !tv.rowSelectable(index) {
+			continue
+		}
+
+		tv.SendMessage(LVM_SETITEMSTATE, uintptr(index), uintptr(unsafe.Pointer(&lvi)))
+	}
+}
+
+// rowSelectable returns if the row at index row can be selected, as reported
+// by the RowSelectableProvider of the model, if any.
+func (tv *TableView) rowSelectable(row int) bool {
+	return tv.rowSelectableProvider == nil || tv.rowSelectableProvider.RowSelectable(row)
+}
+
+// deselectUnselectableRows deselects the rows from index from to index to that
+// the model does not allow to be selected.
+func (tv *TableView) deselectUnselectableRows(from, to int) {
+	var lvi LVITEM
+	lvi.StateMask = LVIS_SELECTED
+
+	for row := from; row <= to; row++ {
+		if tv.rowSelectable(row) {
+			continue
+		}
+
+		if LVIS_SELECTED&tv.SendMessage(LVM_GETITEMSTATE, uintptr(row), LVIS_SELECTED) == 0 {
+			continue
+		}
+
+		tv.SendMessage(LVM_SETITEMSTATE, uintptr(row), uintptr(unsafe.Pointer(&lvi)))
+	}
+}
+
+// syncSelectionModel updates the *SelectionModel from the selected items.
+func (tv *TableView) syncSelectionModel() {
+	if tv.selectionModel == nil || tv.applyingSelection {
+		return
+	}
+
+	count := int(tv.SendMessage(LVM_GETSELECTEDCOUNT, 0, 0))
+	indexes := make([]int, count)
+
+	j := -1
+	for i := 0; i < count; i++ {
+		j = int(tv.SendMessage(LVM_GETNEXTITEM, uintptr(j), LVNI_SELECTED))
+		indexes[i] = j
+	}
+
+	// The *SelectionModel publishes SelectionChanged, which must not be
+	// applied back to the items we just read it from.
+	tv.applyingSelection = true
+	defer func() {
+		tv.applyingSelection = false
+	}()
+
+	tv.selectionModel.SetSelectedIndexes(indexes)
+}
+
 // ItemStateChangedEventDelay returns the delay in milliseconds, between the
 // moment the state of an item in the *TableView changes and the moment the
 // associated event is published.
@@ -593,6 +1209,18 @@ func (tv *TableView) SaveState() error {
 		buf.WriteString(strconv.Itoa(int(idx)))
 	}
 
+	buf.WriteString(";")
+
+	if sorter, ok := tv.model.(Sorter); ok {
+		buf.WriteString(fmt.Sprintf("%d %d", sorter.SortedColumn(), sorter.SortOrder()))
+	}
+
+	buf.WriteString(";")
+
+	if pm, ok := tv.model.(PersistableModel); ok {
+		buf.WriteString(pm.SaveState())
+	}
+
 	return tv.putState(buf.String())
 }
 
@@ -606,7 +1234,8 @@ func (tv *TableView) RestoreState() error {
 		return nil
 	}
 
-	parts := strings.Split(state, ";")
+	// The state of a PersistableModel comes last and may contain semicolons.
+	parts := strings.SplitN(state, ";", 4)
 
 	widthStrs := strings.Split(parts[0], " ")
 
@@ -652,6 +1281,30 @@ func (tv *TableView) RestoreState() error {
 		}
 	}
 
+	if len(parts) > 2 && parts[2] != "" {
+		if sorter, ok := tv.model.(Sorter); ok {
+			var col int
+			var order SortOrder
+			if _, err := fmt.Sscanf(parts[2], "%d %d", &col, &order); err != nil {
+				return err
+			}
+
+			if col >= -1 && col < len(tv.columns) && (col == -1 || sorter.ColumnSortable(col)) {
+				if err := sorter.Sort(col, order); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(parts) > 3 {
+		if pm, ok := tv.model.(PersistableModel); ok {
+			if err := pm.RestoreState(parts[3]); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -734,6 +1387,30 @@ func (tv *TableView) imageIndex(image interface{}) int32 {
 	return imageIndex
 }
 
+// SetAllChecked checks or unchecks all items of the *TableView.
+//
+// If the model implements BulkItemChecker, its SetAllChecked method is used,
+// otherwise SetChecked is called for each row.
+func (tv *TableView) SetAllChecked(checked bool) error {
+	if tv.itemChecker == nil {
+		return newError("model must implement ItemChecker")
+	}
+
+	if bic, ok := tv.itemChecker.(BulkItemChecker); ok {
+		if err := bic.SetAllChecked(checked); err != nil {
+			return wrapError(err)
+		}
+	} else {
+		for row, count := 0, tv.model.RowCount(); row < count; row++ {
+			if err := tv.itemChecker.SetChecked(row, checked); err != nil {
+				return wrapError(err)
+			}
+		}
+	}
+
+	return tv.Invalidate()
+}
+
 func (tv *TableView) toggleItemChecked(index int) error {
 	checked := tv.itemChecker.Checked(index)
 
@@ -765,6 +1442,27 @@ func (tv *TableView) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uint
 			return DLGC_WANTALLKEYS
 		}
 
+	case WM_PAINT, WM_SIZE:
+		result := tv.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+
+		if lsp, ok := tv.model.(LoadingStateProvider); ok && lsp.IsLoading() {
+			if msg == WM_SIZE {
+				// The centered text moves, so the whole area needs a repaint.
+				tv.Invalidate()
+			} else {
+				tv.drawLoadingOverlay()
+			}
+		} else if etp, ok := tv.model.(EmptyTextProvider); ok && tv.model.RowCount() == 0 {
+			if msg == WM_SIZE {
+				// The centered text moves, so the whole area needs a repaint.
+				tv.Invalidate()
+			} else {
+				tv.drawEmptyText(etp.EmptyText(), tv.itemsBounds())
+			}
+		}
+
+		return result
+
 	case WM_LBUTTONDOWN, WM_RBUTTONDOWN, WM_LBUTTONDBLCLK, WM_RBUTTONDBLCLK:
 		var hti LVHITTESTINFO
 		hti.Pt = POINT{GET_X_LPARAM(lParam), GET_Y_LPARAM(lParam)}
@@ -803,39 +1501,12 @@ func (tv *TableView) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uint
 			row := int(di.Item.IItem)
 			col := int(di.Item.ISubItem)
 
-			if di.Item.Mask&LVIF_TEXT > 0 {
-				var text string
-				switch val := tv.model.Value(row, col).(type) {
-				case string:
-					text = val
-
-				case float32:
-					prec := tv.columns[col].Precision
-					if prec == 0 {
-						prec = 2
-					}
-					text, _ = formatFloat(float64(val), prec)
-
-				case float64:
-					prec := tv.columns[col].Precision
-					if prec == 0 {
-						prec = 2
-					}
-					text, _ = formatFloat(val, prec)
-
-				case time.Time:
-					text = val.Format(tv.columns[col].Format)
-
-				case *big.Rat:
-					prec := tv.columns[col].Precision
-					if prec == 0 {
-						prec = 2
-					}
-					text, _ = formatRat(val, prec)
+			if tv.accessibleValueProvider != nil && !tv.accessibleRows[row] {
+				tv.applyAccessibleValue(row)
+			}
 
-				default:
-					text = fmt.Sprintf(tv.columns[col].Format, val)
-				}
+			if di.Item.Mask&LVIF_TEXT > 0 {
+				text := formatCellText(tv.model.Value(row, col), &tv.columns[col])
 
 				utf16 := syscall.StringToUTF16(text)
 				buf := (*[256]uint16)(unsafe.Pointer(di.Item.PszText))
@@ -880,7 +1551,7 @@ func (tv *TableView) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uint
 			}
 
 		case NM_CUSTOMDRAW:
-			if tv.alternatingRowBGColor != defaultTVRowBGColor {
+			if tv.alternatingRowBGColor != defaultTVRowBGColor || tv.cellSpanProvider != nil {
 				nmlvcd := (*NMLVCUSTOMDRAW)(unsafe.Pointer(lParam))
 
 				switch nmlvcd.Nmcd.DwDrawStage {
@@ -891,6 +1562,19 @@ func (tv *TableView) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uint
 					if nmlvcd.Nmcd.DwItemSpec%2 == 1 {
 						nmlvcd.ClrTextBk = COLORREF(tv.alternatingRowBGColor)
 					}
+
+					if tv.cellSpanProvider != nil {
+						return CDRF_NOTIFYSUBITEMDRAW
+					}
+
+				case CDDS_ITEMPREPAINT | CDDS_SUBITEM:
+					row := int(nmlvcd.Nmcd.DwItemSpec)
+
+					if tv.drawSpannedCell(nmlvcd.Nmcd.Hdc, row, int(nmlvcd.ISubItem)) {
+						return CDRF_SKIPDEFAULT
+					}
+
+					nmlvcd.ClrTextBk = COLORREF(tv.rowBGColor(row))
 				}
 			}
 
@@ -917,6 +1601,15 @@ func (tv *TableView) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uint
 			nmlv := (*NMLISTVIEW)(unsafe.Pointer(lParam))
 			selectedNow := nmlv.UNewState&LVIS_SELECTED > 0
 			selectedBefore := nmlv.UOldState&LVIS_SELECTED > 0
+			if selectedNow && !selectedBefore && tv.rowSelectableProvider != nil {
+				if nmlv.IItem == -1 {
+					tv.deselectUnselectableRows(0, tv.model.RowCount()-1)
+				} else if !tv.rowSelectable(int(nmlv.IItem)) {
+					// Deselecting the row sends another LVN_ITEMCHANGED.
+					tv.deselectUnselectableRows(int(nmlv.IItem), int(nmlv.IItem))
+					break
+				}
+			}
 			if selectedNow && !selectedBefore {
 				tv.currentIndex = int(nmlv.IItem)
 				if tv.itemStateChangedEventDelay > 0 {
@@ -935,9 +1628,30 @@ func (tv *TableView) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uint
 			if !tv.SingleItemSelection() {
 				tv.updateSelectedIndexes()
 			}
+			if nmlv.UChanged&LVIF_STATE > 0 && selectedNow != selectedBefore {
+				tv.syncSelectionModel()
+			}
+
+		case LVN_ODSTATECHANGED:
+			// A virtual list view reports range selections, e.g. by Shift+Click,
+			// only through this notification.
+			nmodsc := (*nmlvODStateChange)(unsafe.Pointer(lParam))
+			if nmodsc.UNewState&LVIS_SELECTED > 0 && tv.rowSelectableProvider != nil {
+				tv.deselectUnselectableRows(int(nmodsc.IFrom), int(nmodsc.ITo))
+			}
+			if (nmodsc.UNewState^nmodsc.UOldState)&LVIS_SELECTED > 0 {
+				if !tv.SingleItemSelection() {
+					tv.updateSelectedIndexes()
+				}
+				tv.syncSelectionModel()
+			}
 
 		case LVN_ITEMACTIVATE:
 			tv.itemActivatedPublisher.Publish()
+
+		case HDN_ENDDRAG:
+			// The new column order is applied after this notification.
+			tv.Synchronize(tv.enforceColumnOrder)
 		}
 
 	case WM_TIMER:
@@ -8,6 +8,7 @@ import . "github.com/lxn/go-winapi"
 
 type ToolButton struct {
 	Button
+	action *Action
 }
 
 func NewToolButton(parent Container) (*ToolButton, error) {
@@ -25,6 +26,52 @@ func NewToolButton(parent Container) (*ToolButton, error) {
 	return tb, nil
 }
 
+func (tb *ToolButton) Dispose() {
+	if tb.action != nil {
+		tb.action.removeChangedHandler(tb)
+		tb.action = nil
+	}
+
+	tb.Button.Dispose()
+}
+
+func (tb *ToolButton) Action() *Action {
+	return tb.action
+}
+
+// SetAction makes the *ToolButton reflect the text, enabled, visible and
+// checked state of action and trigger action when clicked.
+//
+// Pass nil to detach the *ToolButton from its current action.
+func (tb *ToolButton) SetAction(action *Action) error {
+	if tb.action != nil {
+		tb.action.removeChangedHandler(tb)
+	}
+
+	tb.action = action
+
+	if action == nil {
+		return nil
+	}
+
+	action.addChangedHandler(tb)
+
+	return tb.onActionChanged(action)
+}
+
+func (tb *ToolButton) onActionChanged(action *Action) error {
+	if err := tb.SetText(action.Text()); err != nil {
+		return err
+	}
+
+	tb.SetEnabled(action.Enabled())
+	tb.SetVisible(action.Visible())
+
+	tb.SendMessage(BM_SETSTATE, uintptr(BoolToBOOL(action.Checkable() && action.Checked())), 0)
+
+	return nil
+}
+
 func (*ToolButton) LayoutFlags() LayoutFlags {
 	return 0
 }
@@ -41,6 +88,14 @@ func (tb *ToolButton) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uin
 	switch msg {
 	case WM_GETDLGCODE:
 		return DLGC_BUTTON
+
+	case WM_COMMAND:
+		switch HIWORD(uint32(wParam)) {
+		case BN_CLICKED:
+			if tb.action != nil {
+				tb.action.raiseTriggered()
+			}
+		}
 	}
 
 	return tb.Button.WndProc(hwnd, msg, wParam, lParam)
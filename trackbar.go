@@ -0,0 +1,155 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"math"
+)
+
+import . "github.com/lxn/go-winapi"
+
+// TrackBar is a slider widget that lets the user select an int value from a
+// range by dragging a thumb.
+type TrackBar struct {
+	WidgetBase
+	valueChangedPublisher EventPublisher
+}
+
+// NewTrackBar creates and returns a new, horizontal *TrackBar as child of the
+// specified Container.
+func NewTrackBar(parent Container) (*TrackBar, error) {
+	tb := &TrackBar{}
+
+	if err := InitChildWidget(
+		tb,
+		parent,
+		"msctls_trackbar32",
+		WS_TABSTOP|WS_VISIBLE,
+		0); err != nil {
+		return nil, err
+	}
+
+	return tb, nil
+}
+
+func (*TrackBar) LayoutFlags() LayoutFlags {
+	return ShrinkableHorz | GrowableHorz | GreedyHorz
+}
+
+func (tb *TrackBar) MinSizeHint() Size {
+	return tb.dialogBaseUnitsToPixels(Size{20, 14})
+}
+
+func (tb *TrackBar) SizeHint() Size {
+	return tb.dialogBaseUnitsToPixels(Size{100, 14})
+}
+
+func (tb *TrackBar) MinValue() int {
+	return int(int32(tb.SendMessage(TBM_GETRANGEMIN, 0, 0)))
+}
+
+func (tb *TrackBar) MaxValue() int {
+	return int(int32(tb.SendMessage(TBM_GETRANGEMAX, 0, 0)))
+}
+
+func (tb *TrackBar) SetRange(min, max int) error {
+	if min > max {
+		return newError("invalid range")
+	}
+
+	tb.SendMessage(TBM_SETRANGEMIN, 0, uintptr(min))
+	tb.SendMessage(TBM_SETRANGEMAX, 1, uintptr(max))
+
+	return nil
+}
+
+func (tb *TrackBar) Value() int {
+	return int(int32(tb.SendMessage(TBM_GETPOS, 0, 0)))
+}
+
+// SetValue moves the thumb of the *TrackBar to value and publishes the
+// ValueChanged event, if the position changed.
+func (tb *TrackBar) SetValue(value int) {
+	if value == tb.Value() {
+		return
+	}
+
+	tb.SendMessage(TBM_SETPOS, 1, uintptr(value))
+
+	tb.valueChangedPublisher.Publish()
+}
+
+// ValueChanged returns the event that is published after the value of the
+// *TrackBar changed.
+func (tb *TrackBar) ValueChanged() *Event {
+	return tb.valueChangedPublisher.Event()
+}
+
+func (tb *TrackBar) WndProc(hwnd HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	switch msg {
+	case WM_HSCROLL, WM_VSCROLL:
+		// The parent forwards the notifications of the thumb being moved.
+		if HWND(lParam) == tb.hWnd {
+			tb.valueChangedPublisher.Publish()
+			return 0
+		}
+	}
+
+	return tb.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+}
+
+// LinkToTrackBar keeps the values of ne and tb in sync, e.g. for a volume
+// control.
+//
+// The spin range of ne is mapped to the range of tb once, scaled by 10 to the
+// power of the decimals of ne, so that each step of tb corresponds to the
+// smallest value ne can display.
+func LinkToTrackBar(ne *NumberEdit, tb *TrackBar) error {
+	scale := math.Pow10(ne.Decimals())
+
+	min, max := ne.SpinRange()
+	min, max = min*scale, max*scale
+	if min < math.MinInt32 || max > math.MaxInt32 {
+		return newError("range of ne does not fit a TrackBar")
+	}
+
+	if err := tb.SetRange(int(math.Floor(min+0.5)), int(math.Floor(max+0.5))); err != nil {
+		return err
+	}
+
+	toTrackBar := func(value float64) int {
+		return int(math.Floor(value*scale + 0.5))
+	}
+
+	var syncing bool
+
+	tb.SetValue(toTrackBar(ne.Value()))
+
+	ne.ValueChanged().Attach(func() {
+		if syncing {
+			return
+		}
+		syncing = true
+		defer func() {
+			syncing = false
+		}()
+
+		tb.SetValue(toTrackBar(ne.Value()))
+	})
+
+	tb.ValueChanged().Attach(func() {
+		if syncing {
+			return
+		}
+		syncing = true
+		defer func() {
+			syncing = false
+		}()
+
+		ne.SetValue(float64(tb.Value()) / scale)
+	})
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+// TriStateCheckerBase implements the TriStateItemChecker and ItemChecker
+// interfaces, including propagation of check states in a hierarchy of items.
+//
+// Checking or unchecking an item applies the same state to all of its
+// descendants. The state of each ancestor is rolled up from its children: it is
+// checked or unchecked if all children are, otherwise it is indeterminate.
+//
+// Items are related by calling SetChildren. The CheckStateChanged event is
+// published for every item whose state changed, so you can forward it to e.g.
+// PublishRowChanged of your model.
+type TriStateCheckerBase struct {
+	states                     map[int]CheckState
+	parents                    map[int]int
+	children                   map[int][]int
+	checkStateChangedPublisher IntEventPublisher
+}
+
+// Children returns the indexes of the child items of the item at index parent.
+func (tcb *TriStateCheckerBase) Children(parent int) []int {
+	return tcb.children[parent]
+}
+
+// SetChildren sets the indexes of the child items of the item at index parent.
+func (tcb *TriStateCheckerBase) SetChildren(parent int, children []int) {
+	if tcb.parents == nil {
+		tcb.parents = make(map[int]int)
+		tcb.children = make(map[int][]int)
+	}
+
+	for _, child := range tcb.children[parent] {
+		delete(tcb.parents, child)
+	}
+
+	tcb.children[parent] = children
+
+	for _, child := range children {
+		tcb.parents[child] = parent
+	}
+}
+
+// Parent returns the index of the parent item of the item at index index, or
+// -1 if it has no parent.
+func (tcb *TriStateCheckerBase) Parent(index int) int {
+	if parent, ok := tcb.parents[index]; ok {
+		return parent
+	}
+
+	return -1
+}
+
+// CheckStateChanged returns the event that is published with the index of each
+// item whose check state changed.
+func (tcb *TriStateCheckerBase) CheckStateChanged() *IntEvent {
+	return tcb.checkStateChangedPublisher.Event()
+}
+
+func (tcb *TriStateCheckerBase) CheckState(index int) CheckState {
+	return tcb.states[index]
+}
+
+// SetCheckState sets the check state of the item at index index, applies it to
+// its descendants, unless it is CheckIndeterminate, and updates its ancestors.
+func (tcb *TriStateCheckerBase) SetCheckState(index int, state CheckState) error {
+	if state < CheckUnchecked || state > CheckIndeterminate {
+		return newError("invalid check state")
+	}
+
+	tcb.setState(index, state)
+
+	if state != CheckIndeterminate {
+		tcb.setDescendantsState(index, state)
+	}
+
+	tcb.updateAncestors(index)
+
+	return nil
+}
+
+func (tcb *TriStateCheckerBase) Checked(index int) bool {
+	return tcb.states[index] == CheckChecked
+}
+
+func (tcb *TriStateCheckerBase) SetChecked(index int, checked bool) error {
+	if checked {
+		return tcb.SetCheckState(index, CheckChecked)
+	}
+
+	return tcb.SetCheckState(index, CheckUnchecked)
+}
+
+func (tcb *TriStateCheckerBase) setState(index int, state CheckState) {
+	if tcb.states[index] == state {
+		return
+	}
+
+	if tcb.states == nil {
+		tcb.states = make(map[int]CheckState)
+	}
+
+	if state == CheckUnchecked {
+		delete(tcb.states, index)
+	} else {
+		tcb.states[index] = state
+	}
+
+	tcb.checkStateChangedPublisher.Publish(index)
+}
+
+func (tcb *TriStateCheckerBase) setDescendantsState(index int, state CheckState) {
+	for _, child := range tcb.children[index] {
+		tcb.setState(child, state)
+		tcb.setDescendantsState(child, state)
+	}
+}
+
+func (tcb *TriStateCheckerBase) updateAncestors(index int) {
+	for parent := tcb.Parent(index); parent != -1; parent = tcb.Parent(parent) {
+		children := tcb.children[parent]
+		if len(children) == 0 {
+			continue
+		}
+
+		state := tcb.states[children[0]]
+		for _, child := range children[1:] {
+			if tcb.states[child] != state {
+				state = CheckIndeterminate
+				break
+			}
+		}
+
+		tcb.setState(parent, state)
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"math"
+)
+
+// ConvertFunc converts value from the unit from to the unit to.
+type ConvertFunc func(value float64, from, to string) float64
+
+// UnitNumberEdit is a composite widget that combines a *NumberEdit with a
+// *ComboBox to select the unit of the value, e.g. for measurements.
+//
+// The *NumberEdit displays the value in the selected unit. Value, SetValue and
+// SetRange work with the first unit, the canonical one. The value is kept in
+// the canonical unit and converted using the ConvertFunc whenever another unit
+// is selected, so switching units does not accumulate rounding errors. Use
+// SetRange instead of setting the range of the *NumberEdit directly.
+//
+// The canonical value can be bound like the value of a *NumberEdit.
+type UnitNumberEdit struct {
+	*Composite
+	numberEdit            *NumberEdit
+	unitComboBox          *ComboBox
+	units                 []string
+	unit                  string
+	convert               ConvertFunc
+	value                 float64
+	minValue              float64
+	maxValue              float64
+	applying              bool
+	bindingMember         string
+	unitChangedPublisher  EventPublisher
+	valueChangedPublisher EventPublisher
+}
+
+// NewUnitNumberEdit returns a new *UnitNumberEdit that offers units, the first
+// of which is the canonical unit, and converts between them using convert.
+func NewUnitNumberEdit(parent Container, units []string, convert ConvertFunc) (*UnitNumberEdit, error) {
+	if len(units) == 0 {
+		return nil, newError("units must not be empty")
+	}
+	if convert == nil {
+		return nil, newError("convert must not be nil")
+	}
+
+	composite, err := NewComposite(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	une := &UnitNumberEdit{
+		Composite: composite,
+		units:     units,
+		unit:      units[0],
+		convert:   convert,
+	}
+
+	succeeded := false
+	defer func() {
+		if !succeeded {
+			une.Dispose()
+		}
+	}()
+
+	layout := NewHBoxLayout()
+	if err := layout.SetMargins(Margins{}); err != nil {
+		return nil, err
+	}
+	if err := une.SetLayout(layout); err != nil {
+		return nil, err
+	}
+
+	if une.numberEdit, err = NewNumberEdit(une); err != nil {
+		return nil, err
+	}
+
+	une.value = une.numberEdit.Value()
+	une.minValue = une.numberEdit.MinValue()
+	une.maxValue = une.numberEdit.MaxValue()
+
+	une.numberEdit.ValueChanged().Attach(func() {
+		if !une.applying {
+			une.setCanonicalValue(une.toCanonical(une.numberEdit.Value()))
+		}
+	})
+
+	if une.unitComboBox, err = NewComboBox(une); err != nil {
+		return nil, err
+	}
+
+	items := make([]interface{}, len(units))
+	for i, unit := range units {
+		items[i] = unit
+	}
+	if err := une.unitComboBox.SetModel(NewSliceListModel(items)); err != nil {
+		return nil, err
+	}
+	if err := une.unitComboBox.SetCurrentIndex(0); err != nil {
+		return nil, err
+	}
+
+	une.unitComboBox.CurrentIndexChanged().Attach(func() {
+		if index := une.unitComboBox.CurrentIndex(); index >= 0 {
+			une.setUnit(une.units[index])
+		}
+	})
+
+	succeeded = true
+
+	return une, nil
+}
+
+// NumberEdit returns the *NumberEdit that displays the value.
+func (une *UnitNumberEdit) NumberEdit() *NumberEdit {
+	return une.numberEdit
+}
+
+// UnitComboBox returns the *ComboBox that selects the unit.
+func (une *UnitNumberEdit) UnitComboBox() *ComboBox {
+	return une.unitComboBox
+}
+
+// Units returns the units the *UnitNumberEdit offers.
+func (une *UnitNumberEdit) Units() []string {
+	return une.units
+}
+
+// Unit returns the unit the value is displayed in.
+func (une *UnitNumberEdit) Unit() string {
+	return une.unit
+}
+
+// SetUnit selects the unit the value is displayed in and converts the
+// displayed value.
+func (une *UnitNumberEdit) SetUnit(unit string) error {
+	for i, u := range une.units {
+		if u == unit {
+			// The CurrentIndexChanged handler takes care of the conversion.
+			return une.unitComboBox.SetCurrentIndex(i)
+		}
+	}
+
+	return newError("unknown unit")
+}
+
+// UnitChanged returns the event that is published after the unit changed.
+func (une *UnitNumberEdit) UnitChanged() *Event {
+	return une.unitChangedPublisher.Event()
+}
+
+func (une *UnitNumberEdit) setUnit(unit string) {
+	if unit == une.unit {
+		return
+	}
+
+	une.unit = unit
+	une.apply(une.value)
+
+	une.unitChangedPublisher.Publish()
+}
+
+func (une *UnitNumberEdit) BindingMember() string {
+	return une.bindingMember
+}
+
+func (une *UnitNumberEdit) SetBindingMember(member string) error {
+	if err := validateBindingMemberSyntax(member); err != nil {
+		return err
+	}
+
+	une.bindingMember = member
+
+	return nil
+}
+
+func (une *UnitNumberEdit) BindingValue() interface{} {
+	return une.Value()
+}
+
+func (une *UnitNumberEdit) SetBindingValue(value interface{}) error {
+	return une.SetValue(value.(float64))
+}
+
+func (une *UnitNumberEdit) BindingValueChanged() *Event {
+	return une.ValueChanged()
+}
+
+// Value returns the value in the canonical unit.
+func (une *UnitNumberEdit) Value() float64 {
+	return une.value
+}
+
+// SetValue sets the value in the canonical unit, clamped to the range. It is
+// displayed in the selected unit.
+func (une *UnitNumberEdit) SetValue(value float64) error {
+	value = math.Max(une.minValue, math.Min(une.maxValue, value))
+
+	if err := une.apply(value); err != nil {
+		return err
+	}
+
+	une.setCanonicalValue(value)
+
+	return nil
+}
+
+// ValueChanged returns the event that is published after the value in the
+// canonical unit changed, but not when only the unit changed.
+func (une *UnitNumberEdit) ValueChanged() *Event {
+	return une.valueChangedPublisher.Event()
+}
+
+func (une *UnitNumberEdit) setCanonicalValue(value float64) {
+	if value == une.value {
+		return
+	}
+
+	une.value = value
+
+	une.valueChangedPublisher.Publish()
+}
+
+// MinValue returns the minimum value in the canonical unit.
+func (une *UnitNumberEdit) MinValue() float64 {
+	return une.minValue
+}
+
+// MaxValue returns the maximum value in the canonical unit.
+func (une *UnitNumberEdit) MaxValue() float64 {
+	return une.maxValue
+}
+
+// SetRange sets the range of the value in the canonical unit. It is converted
+// to the selected unit for the *NumberEdit.
+func (une *UnitNumberEdit) SetRange(min, max float64) error {
+	if min > max {
+		return newError("invalid range")
+	}
+
+	une.minValue, une.maxValue = min, max
+
+	return une.SetValue(une.value)
+}
+
+// apply converts the canonical range and value to the selected unit and sets
+// them on the *NumberEdit.
+func (une *UnitNumberEdit) apply(value float64) error {
+	une.applying = true
+	defer func() {
+		une.applying = false
+	}()
+
+	min, max := une.fromCanonical(une.minValue), une.fromCanonical(une.maxValue)
+	if min > max {
+		min, max = max, min
+	}
+
+	if err := une.numberEdit.SetRange(min, max); err != nil {
+		return err
+	}
+
+	return une.numberEdit.SetValue(une.fromCanonical(value))
+}
+
+func (une *UnitNumberEdit) fromCanonical(value float64) float64 {
+	if une.unit == une.units[0] {
+		return value
+	}
+
+	return une.convert(value, une.units[0], une.unit)
+}
+
+func (une *UnitNumberEdit) toCanonical(value float64) float64 {
+	if une.unit == une.units[0] {
+		return value
+	}
+
+	return une.convert(value, une.unit, une.units[0])
+}
@@ -5,10 +5,12 @@
 package walk
 
 import (
+	"errors"
 	"math/big"
 	"strconv"
 	"strings"
 	"syscall"
+	"unsafe"
 )
 
 import (
@@ -40,9 +42,41 @@ func boolToInt(value bool) int {
 }
 
 func parseFloat(s string) (float64, error) {
+	return parseFloatLocale(s, LOCALE_USER_DEFAULT)
+}
+
+func parseFloatLocale(s string, locale LCID) (float64, error) {
+	return strconv.ParseFloat(canonicalFloatStringLocale(s, locale), 64)
+}
+
+var errInvalidNumber = errors.New("invalid number")
+
+func parseRat(s string) (*big.Rat, error) {
+	return parseRatLocale(s, LOCALE_USER_DEFAULT)
+}
+
+func parseRatLocale(s string, locale LCID) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(canonicalFloatStringLocale(s, locale))
+	if !ok {
+		// Not a walk error, so partial input doesn't get logged or panic.
+		return nil, errInvalidNumber
+	}
+
+	return r, nil
+}
+
+// canonicalFloatString removes the locale specific thousands separators from s
+// and replaces the decimal separator with a dot.
+func canonicalFloatString(s string) string {
+	return canonicalFloatStringLocale(s, LOCALE_USER_DEFAULT)
+}
+
+// canonicalFloatStringLocale is like canonicalFloatString, but uses the
+// separators of locale.
+func canonicalFloatStringLocale(s string, locale LCID) string {
 	s = strings.TrimSpace(s)
 
-	t, _ := formatFloat(1000, 2)
+	t, _ := formatFloatLocale(1000, 2, locale)
 
 	replaceSep := func(new string, index func(string, func(rune) bool) int) {
 		i := index(t, func(r rune) bool {
@@ -61,24 +95,130 @@ func parseFloat(s string) (float64, error) {
 	replaceSep("", strings.IndexFunc)
 	replaceSep(".", strings.LastIndexFunc)
 
-	return strconv.ParseFloat(s, 64)
+	return s
 }
 
 func formatFloat(f float64, prec int) (string, error) {
-	return formatFloatString(strconv.FormatFloat(f, 'f', prec, 64), prec)
+	return formatFloatLocale(f, prec, LOCALE_USER_DEFAULT)
+}
+
+func formatFloatLocale(f float64, prec int, locale LCID) (string, error) {
+	return formatFloatStringLocale(strconv.FormatFloat(f, 'f', prec, 64), prec, locale)
+}
+
+// formatFloatLocalePrec is like formatFloatLocale, but formats f with exactly
+// prec decimals instead of the default number of decimals of locale.
+func formatFloatLocalePrec(f float64, prec int, locale LCID) (string, error) {
+	var info [5]string
+	for i, lcType := range []uint32{localeSDecimal, localeSThousand, localeSGrouping, localeILZero, localeINegNumber} {
+		s, err := localeInfo(locale, lcType)
+		if err != nil {
+			return "", err
+		}
+
+		info[i] = s
+	}
+
+	// The locale has e.g. "3;2;0" for groups of 3, then repeated groups of 2,
+	// which NUMBERFMT encodes as 32, while "3" alone is encoded as 30.
+	grouping := strings.Replace(info[2], ";", "", -1)
+	if strings.HasSuffix(grouping, "0") {
+		grouping = grouping[:len(grouping)-1]
+	} else {
+		grouping += "0"
+	}
+
+	groupingNum, _ := strconv.Atoi(grouping)
+	leadingZero, _ := strconv.Atoi(info[3])
+	negativeOrder, _ := strconv.Atoi(info[4])
+
+	nf := numberFmt{
+		NumDigits:     uint32(prec),
+		LeadingZero:   uint32(leadingZero),
+		Grouping:      uint32(groupingNum),
+		LpDecimalSep:  syscall.StringToUTF16Ptr(info[0]),
+		LpThousandSep: syscall.StringToUTF16Ptr(info[1]),
+		NegativeOrder: uint32(negativeOrder),
+	}
+
+	sPtr := uintptr(unsafe.Pointer(syscall.StringToUTF16Ptr(strconv.FormatFloat(f, 'f', prec, 64))))
+	nfPtr := uintptr(unsafe.Pointer(&nf))
+
+	bufSize, _, _ := getNumberFormat.Call(uintptr(locale), 0, sPtr, nfPtr, 0, 0)
+	if bufSize == 0 {
+		return "", lastError("GetNumberFormat")
+	}
+
+	buf := make([]uint16, bufSize)
+
+	if ret, _, _ := getNumberFormat.Call(uintptr(locale), 0, sPtr, nfPtr, uintptr(unsafe.Pointer(&buf[0])), bufSize); ret == 0 {
+		return "", lastError("GetNumberFormat")
+	}
+
+	return syscall.UTF16ToString(buf), nil
+}
+
+// localeInfo returns the information of type lcType about locale.
+func localeInfo(locale LCID, lcType uint32) (string, error) {
+	bufSize, _, _ := getLocaleInfo.Call(uintptr(locale), uintptr(lcType), 0, 0)
+	if bufSize == 0 {
+		return "", lastError("GetLocaleInfo")
+	}
+
+	buf := make([]uint16, bufSize)
+
+	if ret, _, _ := getLocaleInfo.Call(uintptr(locale), uintptr(lcType), uintptr(unsafe.Pointer(&buf[0])), bufSize); ret == 0 {
+		return "", lastError("GetLocaleInfo")
+	}
+
+	return syscall.UTF16ToString(buf), nil
 }
 
 func formatRat(r *big.Rat, prec int) (string, error) {
-	return formatFloatString(r.FloatString(prec), prec)
+	return formatRatLocale(r, prec, LOCALE_USER_DEFAULT)
+}
+
+func formatRatLocale(r *big.Rat, prec int, locale LCID) (string, error) {
+	return formatFloatStringLocale(r.FloatString(prec), prec, locale)
+}
+
+// formatCurrencyString formats the number s as currency of the user's locale,
+// including the currency symbol.
+func formatCurrencyString(s string) (string, error) {
+	sPtr := uintptr(unsafe.Pointer(syscall.StringToUTF16Ptr(s)))
+
+	bufSize, _, _ := getCurrencyFormat.Call(uintptr(LOCALE_USER_DEFAULT), 0, sPtr, 0, 0, 0)
+	if bufSize == 0 {
+		return "", lastError("GetCurrencyFormat")
+	}
+
+	buf := make([]uint16, bufSize)
+
+	if ret, _, _ := getCurrencyFormat.Call(
+		uintptr(LOCALE_USER_DEFAULT),
+		0,
+		sPtr,
+		0,
+		uintptr(unsafe.Pointer(&buf[0])),
+		bufSize); ret == 0 {
+
+		return "", lastError("GetCurrencyFormat")
+	}
+
+	return UTF16PtrToString(&buf[0]), nil
 }
 
 func formatFloatString(s string, prec int) (string, error) {
+	return formatFloatStringLocale(s, prec, LOCALE_USER_DEFAULT)
+}
+
+func formatFloatStringLocale(s string, prec int, locale LCID) (string, error) {
 	// FIXME: Currently precision is ignored, because passing a *NUMBERFMT
 	// with only NumDigits initialized causes GetNumberFormat to fail.
 	sPtr := syscall.StringToUTF16Ptr(s)
 
 	bufSize := GetNumberFormat(
-		LOCALE_USER_DEFAULT,
+		locale,
 		0,
 		sPtr,
 		nil,
@@ -97,7 +237,7 @@ func formatFloatString(s string, prec int) (string, error) {
 	buf := make([]uint16, bufSize)
 
 	if 0 == GetNumberFormat(
-		LOCALE_USER_DEFAULT,
+		locale,
 		0,
 		sPtr,
 		nil,
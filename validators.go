@@ -4,32 +4,71 @@
 
 package walk
 
-import "strconv"
+import (
+	"math"
+	"strings"
+)
+
+import . "github.com/lxn/go-winapi"
 
+// ValidationStatus is the result of validating text.
 type ValidationStatus uint
 
 const (
+	// Invalid means the text can not become valid by appending to it.
 	Invalid ValidationStatus = iota
+
+	// Partial means the text is not valid yet, but may become valid, e.g. a
+	// number below the minimum value while the user is still typing.
 	Partial
+
+	// Valid means the text is acceptable.
 	Valid
 )
 
+// Validator is the interface that validates the text of a widget like
+// LineEdit.
 type Validator interface {
 	Validate(s string) ValidationStatus
 }
 
+// NumberValidator is a Validator for numbers within a range, with a fixed
+// number of decimals.
+//
+// NumberEdit uses a *NumberValidator to hold its range and number of decimals.
+// LineEdit does not call Validate while the user types, so call it yourself,
+// e.g. before accepting a dialog.
 type NumberValidator struct {
 	decimals int
 	minValue float64
 	maxValue float64
 }
 
+// NewNumberValidator returns a new *NumberValidator with 0 decimals and the
+// range [0, 0].
 func NewNumberValidator() *NumberValidator {
 	return &NumberValidator{}
 }
 
+// NewNumberValidatorWithRange returns a new *NumberValidator for the range
+// [min, max] with the specified number of decimals.
+func NewNumberValidatorWithRange(min, max float64, decimals int) (*NumberValidator, error) {
+	nv := new(NumberValidator)
+
+	if err := nv.SetRange(min, max); err != nil {
+		return nil, err
+	}
+	if err := nv.SetDecimals(decimals); err != nil {
+		return nil, err
+	}
+
+	return nv, nil
+}
+
+// Validate returns if s is a number within the range, formatted the way Fixup
+// formats it.
 func (nv *NumberValidator) Validate(s string) ValidationStatus {
-	num, err := strconv.ParseFloat(s, 64)
+	num, err := parseFloatLocale(s, LOCALE_USER_DEFAULT)
 	if err != nil {
 		return Invalid
 	}
@@ -42,19 +81,41 @@ func (nv *NumberValidator) Validate(s string) ValidationStatus {
 		return Invalid
 	}
 
-	str := strconv.FormatFloat(num, 'f', nv.decimals, 64)
-
-	if s != str {
+	if str, err := formatFloatLocalePrec(num, nv.decimals, LOCALE_USER_DEFAULT); err != nil || s != str {
 		return Invalid
 	}
 
 	return Valid
 }
 
+// Fixup returns s corrected to a valid text, if possible, by clamping it to the
+// range and formatting it with the configured number of decimals.
+//
+// s is parsed and formatted with the separators and digit grouping of the
+// user's locale, like the text of a NumberEdit. If s is not a number at all, it
+// is returned unchanged.
+func (nv *NumberValidator) Fixup(s string) string {
+	num, err := parseFloatLocale(strings.TrimSpace(s), LOCALE_USER_DEFAULT)
+	if err != nil {
+		return s
+	}
+
+	num = math.Max(nv.minValue, math.Min(nv.maxValue, num))
+
+	text, err := formatFloatLocalePrec(num, nv.decimals, LOCALE_USER_DEFAULT)
+	if err != nil {
+		return s
+	}
+
+	return text
+}
+
+// Decimals returns the number of decimals a valid number has.
 func (nv *NumberValidator) Decimals() int {
 	return nv.decimals
 }
 
+// SetDecimals sets the number of decimals a valid number has.
 func (nv *NumberValidator) SetDecimals(value int) error {
 	if value < 0 {
 		return newError("invalid value")
@@ -65,14 +126,17 @@ func (nv *NumberValidator) SetDecimals(value int) error {
 	return nil
 }
 
+// MinValue returns the minimum valid number.
 func (nv *NumberValidator) MinValue() float64 {
 	return nv.minValue
 }
 
+// MaxValue returns the maximum valid number.
 func (nv *NumberValidator) MaxValue() float64 {
 	return nv.maxValue
 }
 
+// SetRange sets the range of valid numbers.
 func (nv *NumberValidator) SetRange(min, max float64) error {
 	if min > max {
 		return newError("invalid range")
@@ -83,3 +147,49 @@ func (nv *NumberValidator) SetRange(min, max float64) error {
 
 	return nil
 }
+
+// MaskValidator is a Validator for text that follows a mask, e.g. "###-###" for
+// a part number. A '#' in the mask stands for a digit, any other character must
+// appear literally.
+//
+// MaskedEdit holds its mask in a *MaskValidator. A LineEdit only stores the
+// validator it is given, so outside of a MaskedEdit, check the text with
+// Validate yourself.
+type MaskValidator struct {
+	mask []rune
+}
+
+// NewMaskValidator returns a new *MaskValidator for mask.
+func NewMaskValidator(mask string) *MaskValidator {
+	return &MaskValidator{mask: []rune(mask)}
+}
+
+// Mask returns the mask text must follow.
+func (mv *MaskValidator) Mask() string {
+	return string(mv.mask)
+}
+
+// Validate returns Valid if s fills the mask completely, Partial if s follows
+// the beginning of the mask and Invalid otherwise.
+func (mv *MaskValidator) Validate(s string) ValidationStatus {
+	text := []rune(s)
+	if len(text) > len(mv.mask) {
+		return Invalid
+	}
+
+	for i, r := range text {
+		if mv.mask[i] == '#' {
+			if r < '0' || r > '9' {
+				return Invalid
+			}
+		} else if r != mv.mask[i] {
+			return Invalid
+		}
+	}
+
+	if len(text) < len(mv.mask) {
+		return Partial
+	}
+
+	return Valid
+}
@@ -295,6 +295,7 @@ type WidgetBase struct {
 	hWnd                 HWND
 	origWndProcPtr       uintptr
 	name                 string
+	accessibleName       string
 	parent               Container
 	font                 *Font
 	contextMenu          *Menu
@@ -306,6 +307,7 @@ type WidgetBase struct {
 	maxSize              Size
 	minSize              Size
 	background           Brush
+	cachedBrushColors    map[Color]bool
 	cursor               Cursor
 	suspended            bool
 	visible              bool
@@ -535,6 +537,26 @@ func (wb *WidgetBase) SetName(name string) {
 	wb.name = name
 }
 
+// AccessibleName returns the name screen readers announce for the *WidgetBase,
+// if it was set using SetAccessibleName.
+func (wb *WidgetBase) AccessibleName() string {
+	return wb.accessibleName
+}
+
+// SetAccessibleName sets the name screen readers announce for the *WidgetBase,
+// e.g. for a button that only displays an image.
+//
+// Pass an empty string to have the default name of the control apply again.
+func (wb *WidgetBase) SetAccessibleName(name string) error {
+	if err := setAccessibleProp(wb.hWnd, childIDSelf, propIDAccName, name); err != nil {
+		return err
+	}
+
+	wb.accessibleName = name
+
+	return nil
+}
+
 func (wb *WidgetBase) writePath(buf *bytes.Buffer) {
 	hWndParent := GetAncestor(wb.hWnd, GA_PARENT)
 	if pwi := widgetFromHWND(hWndParent); pwi != nil {
@@ -566,9 +588,40 @@ func (wb *WidgetBase) BaseWidget() *WidgetBase {
 // as well.
 func (wb *WidgetBase) Dispose() {
 	if wb.hWnd != 0 {
+		if wb.accessibleName != "" {
+			clearAccessibleProps(wb.hWnd, childIDSelf)
+		}
+
 		DestroyWindow(wb.hWnd)
 		wb.hWnd = 0
 	}
+
+	for color := range wb.cachedBrushColors {
+		releaseSolidColorBrush(color)
+	}
+	wb.cachedBrushColors = nil
+}
+
+// cachedSolidColorBrush returns a shared *SolidColorBrush for color, that is
+// released when the *WidgetBase is disposed.
+//
+// Painting code should use this instead of creating a brush per paint.
+func (wb *WidgetBase) cachedSolidColorBrush(color Color) (*SolidColorBrush, error) {
+	if wb.cachedBrushColors[color] {
+		return solidColorBrushCache[color].brush, nil
+	}
+
+	brush, err := acquireSolidColorBrush(color)
+	if err != nil {
+		return nil, err
+	}
+
+	if wb.cachedBrushColors == nil {
+		wb.cachedBrushColors = make(map[Color]bool)
+	}
+	wb.cachedBrushColors[color] = true
+
+	return brush, nil
 }
 
 // IsDisposed returns if the *WidgetBase has been disposed of.
@@ -701,6 +754,169 @@ func (wb *WidgetBase) Invalidate() error {
 	return nil
 }
 
+// drawEmptyText draws text centered into bounds, as displayed by model based
+// widgets that have no items.
+func (wb *WidgetBase) drawEmptyText(text string, bounds Rectangle) {
+	if text == "" {
+		return
+	}
+
+	canvas, err := newCanvasFromHWND(wb.hWnd)
+	if err != nil {
+		return
+	}
+	defer canvas.Dispose()
+
+	canvas.DrawText(
+		text,
+		wb.Font(),
+		Color(GetSysColor(COLOR_GRAYTEXT)),
+		bounds,
+		TextCenter|TextVCenter|TextSingleLine|TextEndEllipsis)
+}
+
+// The owner drawing states of DRAWITEMSTRUCT. go-winapi defines the ODS_
+// constants with wrong values.
+const (
+	odsSelected     = 0x0001
+	odsDisabled     = 0x0004
+	odsFocus        = 0x0010
+	odsComboBoxEdit = 0x1000
+)
+
+var lastCtrlID int32 = 1000
+
+// newCtrlID returns a new control id, e.g. to route WM_MEASUREITEM to an owner
+// drawn widget.
+func newCtrlID() int32 {
+	lastCtrlID++
+
+	return lastCtrlID
+}
+
+// recreateWindow replaces the window of the *WidgetBase with a new one of
+// className and style, that keeps the place, id and font of the old one.
+//
+// Controls like list boxes and combo boxes honor some styles, e.g. the owner
+// draw ones, only at creation. Items are not carried over, and neither is other
+// state that Windows keeps per window and walk does not track, e.g. the tools
+// of a ToolTip, which must be added again.
+func (wb *WidgetBase) recreateWindow(className string, style uint32) error {
+	hWndOld := wb.hWnd
+	bounds := wb.Bounds()
+	id := GetWindowLong(hWndOld, GWLP_ID)
+
+	hWnd := CreateWindowEx(
+		uint32(GetWindowLong(hWndOld, GWL_EXSTYLE)),
+		syscall.StringToUTF16Ptr(className),
+		nil,
+		style,
+		int32(bounds.X),
+		int32(bounds.Y),
+		int32(bounds.Width),
+		int32(bounds.Height),
+		GetAncestor(hWndOld, GA_PARENT),
+		HMENU(id),
+		0,
+		nil)
+	if hWnd == 0 {
+		return lastError("CreateWindowEx")
+	}
+
+	// Keep the z-order, and with it the tab order.
+	SetWindowPos(hWnd, hWndOld, 0, 0, 0, 0, SWP_NOMOVE|SWP_NOSIZE|SWP_NOACTIVATE)
+
+	SetWindowLongPtr(hWndOld, GWLP_WNDPROC, wb.origWndProcPtr)
+	SetWindowLongPtr(hWndOld, GWLP_USERDATA, 0)
+	DestroyWindow(hWndOld)
+
+	wb.hWnd = hWnd
+
+	SetWindowLongPtr(hWnd, GWLP_USERDATA, uintptr(unsafe.Pointer(wb)))
+	wb.origWndProcPtr = SetWindowLongPtr(hWnd, GWLP_WNDPROC, widgetWndProcPtr)
+	if wb.origWndProcPtr == 0 {
+		return lastError("SetWindowLongPtr")
+	}
+
+	setWidgetFont(hWnd, wb.Font())
+
+	if wb.accessibleName != "" {
+		return setAccessibleProp(hWnd, childIDSelf, propIDAccName, wb.accessibleName)
+	}
+
+	return nil
+}
+
+// drawListItem draws an item of an owner drawn list box or combo box, as
+// described by dis, in the system colors.
+func (wb *WidgetBase) drawListItem(dis *DRAWITEMSTRUCT, text string, font *Font) {
+	canvas, err := newCanvasFromHDC(dis.HDC)
+	if err != nil {
+		return
+	}
+	defer canvas.Dispose()
+
+	bgColor, textColor := COLOR_WINDOW, COLOR_WINDOWTEXT
+	if dis.ItemState&odsSelected != 0 {
+		bgColor, textColor = COLOR_HIGHLIGHT, COLOR_HIGHLIGHTTEXT
+	}
+	if dis.ItemState&odsDisabled != 0 {
+		textColor = COLOR_GRAYTEXT
+	}
+
+	bounds := rectangleFromRECT(dis.RcItem)
+
+	brush, err := wb.cachedSolidColorBrush(Color(GetSysColor(bgColor)))
+	if err != nil {
+		return
+	}
+	canvas.FillRectangle(brush, bounds)
+
+	if dis.ItemID >= 0 {
+		if font == nil {
+			font = wb.Font()
+		}
+
+		bounds.X += 2
+		bounds.Width -= 4
+
+		canvas.DrawText(
+			text,
+			font,
+			Color(GetSysColor(textColor)),
+			bounds,
+			TextLeft|TextVCenter|TextSingleLine|TextEndEllipsis|TextNoPrefix)
+	}
+
+	if dis.ItemState&odsFocus != 0 {
+		rc := dis.RcItem
+		DrawFocusRect(dis.HDC, &rc)
+	}
+}
+
+// textHeight returns the height of a line of text in the font of the
+// *WidgetBase, e.g. for the item height of an owner drawn list.
+func (wb *WidgetBase) textHeight() int {
+	hdc := GetDC(wb.hWnd)
+	if hdc == 0 {
+		newError("GetDC failed")
+		return 0
+	}
+	defer ReleaseDC(wb.hWnd, hdc)
+
+	hFontOld := SelectObject(hdc, HGDIOBJ(wb.Font().handleForDPI(0)))
+	defer SelectObject(hdc, hFontOld)
+
+	var s SIZE
+	str := syscall.StringToUTF16("gG")
+	if !GetTextExtentPoint32(hdc, &str[0], int32(len(str)-1), &s) {
+		newError("GetTextExtentPoint32 failed")
+		return 0
+	}
+
+	return int(s.CY)
+}
+
 // Parent returns the Container of the *WidgetBase.
 //
 // For RootWidgets, like *MainWindow and *Dialog, this is always nil.
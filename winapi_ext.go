@@ -0,0 +1,161 @@
+// Copyright 2012 The Walk Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package walk
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+import . "github.com/lxn/go-winapi"
+
+// This file declares the parts of the Windows API that go-winapi does not wrap
+// yet. Move them over to go-winapi once it does.
+
+var (
+	libgdi32    = syscall.NewLazyDLL("gdi32.dll")
+	libkernel32 = syscall.NewLazyDLL("kernel32.dll")
+	libuser32   = syscall.NewLazyDLL("user32.dll")
+	libwinspool = syscall.NewLazyDLL("winspool.drv")
+
+	abortDoc           = libgdi32.NewProc("AbortDoc")
+	copyIcon           = libuser32.NewProc("CopyIcon")
+	createDC           = libgdi32.NewProc("CreateDCW")
+	getCurrencyFormat  = libkernel32.NewProc("GetCurrencyFormatW")
+	getDefaultPrinter  = libwinspool.NewProc("GetDefaultPrinterW")
+	getEnhMetaFileBits = libgdi32.NewProc("GetEnhMetaFileBits")
+	getLocaleInfo      = libkernel32.NewProc("GetLocaleInfoW")
+	getNumberFormat    = libkernel32.NewProc("GetNumberFormatW")
+	plgBlt             = libgdi32.NewProc("PlgBlt")
+	setGraphicsMode    = libgdi32.NewProc("SetGraphicsMode")
+	startDoc           = libgdi32.NewProc("StartDocW")
+)
+
+// SetGraphicsMode modes
+const gmAdvanced = 2
+
+// GetLocaleInfo information types
+const (
+	localeSDecimal   = 0x000E
+	localeSThousand  = 0x000F
+	localeSGrouping  = 0x0010
+	localeILZero     = 0x0012
+	localeINegNumber = 0x1010
+)
+
+// NUMBERFMT
+type numberFmt struct {
+	NumDigits     uint32
+	LeadingZero   uint32
+	Grouping      uint32
+	LpDecimalSep  *uint16
+	LpThousandSep *uint16
+	NegativeOrder uint32
+}
+
+// DOCINFO
+type docInfo struct {
+	CbSize       int32
+	LpszDocName  *uint16
+	LpszOutput   *uint16
+	LpszDatatype *uint16
+	FwType       uint32
+}
+
+// NMLVODSTATECHANGE
+type nmlvODStateChange struct {
+	Hdr       NMHDR
+	IFrom     int32
+	ITo       int32
+	UNewState uint32
+	UOldState uint32
+}
+
+// OBJID_CLIENT and CHILDID_SELF
+const (
+	objIDClient = -4
+	childIDSelf = 0
+)
+
+// MSAAPROPID
+type msaaPropID syscall.GUID
+
+var (
+	propIDAccName  = msaaPropID{0x608d3df8, 0x8128, 0x4aa7, [8]byte{0xa4, 0x28, 0xf5, 0x5e, 0x49, 0x26, 0x72, 0x91}}
+	propIDAccValue = msaaPropID{0x123fe443, 0x211a, 0x4615, [8]byte{0x95, 0x27, 0xc4, 0x5a, 0x7e, 0x93, 0x71, 0x7a}}
+)
+
+var (
+	clsidAccPropServices = CLSID{Data1: 0xb5f8350b, Data2: 0x0548, Data3: 0x48b1, Data4: [8]byte{0xa6, 0xee, 0x88, 0xbd, 0x00, 0xb4, 0xa5, 0xe7}}
+	iidIAccPropServices  = IID{Data1: 0x6e26e776, Data2: 0x04f0, Data3: 0x495d, Data4: [8]byte{0x80, 0xe4, 0x33, 0x30, 0x35, 0x2e, 0x31, 0x69}}
+)
+
+type iAccPropServicesVtbl struct {
+	QueryInterface               uintptr
+	AddRef                       uintptr
+	Release                      uintptr
+	SetPropValue                 uintptr
+	SetPropServer                uintptr
+	ClearProps                   uintptr
+	SetHwndProp                  uintptr
+	SetHwndPropStr               uintptr
+	SetHwndPropServer            uintptr
+	ClearHwndProps               uintptr
+	ComposeHwndIdentityString    uintptr
+	DecomposeHwndIdentityString  uintptr
+	SetHmenuProp                 uintptr
+	SetHmenuPropStr              uintptr
+	SetHmenuPropServer           uintptr
+	ClearHmenuProps              uintptr
+	ComposeHmenuIdentityString   uintptr
+	DecomposeHmenuIdentityString uintptr
+}
+
+type iAccPropServices struct {
+	LpVtbl *iAccPropServicesVtbl
+}
+
+func (obj *iAccPropServices) SetHwndPropStr(hwnd HWND, idObject int32, idChild uint32, idProp *msaaPropID, str string) HRESULT {
+	var ret uintptr
+
+	// The MSAAPROPID is passed by value, which means by reference on amd64,
+	// but as four stack words on 386.
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		ret, _, _ = syscall.Syscall6(obj.LpVtbl.SetHwndPropStr, 6,
+			uintptr(unsafe.Pointer(obj)),
+			uintptr(hwnd),
+			uintptr(idObject),
+			uintptr(idChild),
+			uintptr(unsafe.Pointer(idProp)),
+			uintptr(unsafe.Pointer(syscall.StringToUTF16Ptr(str))))
+	} else {
+		words := (*[4]uintptr)(unsafe.Pointer(idProp))
+
+		ret, _, _ = syscall.Syscall9(obj.LpVtbl.SetHwndPropStr, 9,
+			uintptr(unsafe.Pointer(obj)),
+			uintptr(hwnd),
+			uintptr(idObject),
+			uintptr(idChild),
+			words[0],
+			words[1],
+			words[2],
+			words[3],
+			uintptr(unsafe.Pointer(syscall.StringToUTF16Ptr(str))))
+	}
+
+	return HRESULT(ret)
+}
+
+func (obj *iAccPropServices) ClearHwndProps(hwnd HWND, idObject int32, idChild uint32, idProps []msaaPropID) HRESULT {
+	ret, _, _ := syscall.Syscall6(obj.LpVtbl.ClearHwndProps, 6,
+		uintptr(unsafe.Pointer(obj)),
+		uintptr(hwnd),
+		uintptr(idObject),
+		uintptr(idChild),
+		uintptr(unsafe.Pointer(&idProps[0])),
+		uintptr(len(idProps)))
+
+	return HRESULT(ret)
+}